@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ParseFilter reads a filter from space-separated key:value terms, e.g.
+// "product:postgres port:5432 since:7d until:2026-01-31". Times accept
+// RFC3339, a date, or a duration (h or d suffix) counted back from now.
+func ParseFilter(query string) (Filter, error) {
+	var filter Filter
+	for _, term := range strings.Fields(query) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			return filter, fmt.Errorf("invalid filter term '%s'", term)
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "product":
+			filter.Product = value
+		case "port":
+			filter.Port, err = strconv.Atoi(value)
+		case "since":
+			filter.Since, err = parseTime(value)
+		case "until":
+			filter.Until, err = parseTime(value)
+		default:
+			return filter, fmt.Errorf("unknown filter '%s'", key)
+		}
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s '%s'", key, value)
+		}
+	}
+	return filter, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Now().AddDate(0, 0, -n), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-d), nil
+}
+
+// Matches reports whether an entry passes the filter
+func (f Filter) Matches(entry Entry) bool {
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			return false
+		}
+		if !f.Since.IsZero() && t.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && t.After(f.Until) {
+			return false
+		}
+	}
+	if f.Product == "" && f.Port == 0 {
+		return true
+	}
+	for _, change := range entry.Changes {
+		if f.matchesChange(change) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) matchesChange(change Change) bool {
+	var rules []models.AppliedRule
+	if change.Before != nil {
+		rules = append(rules, *change.Before)
+	}
+	if change.After != nil {
+		rules = append(rules, *change.After)
+	}
+	if op := change.Operation; op != nil {
+		if op.NAT != nil {
+			rules = append(rules, models.NewAppliedNATRule(*op.NAT, ""))
+		}
+		if op.Firewall != nil {
+			rules = append(rules, models.NewAppliedFirewallRule(*op.Firewall, ""))
+		}
+	}
+
+	for _, r := range rules {
+		if f.Product != "" && !strings.EqualFold(r.Product, f.Product) {
+			continue
+		}
+		if f.Port != 0 && r.ExternalPort != f.Port && r.Port != f.Port && r.InternalPort != f.Port {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/integrity"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// Journal appends entries to a JSON lines file
+type Journal struct {
+	path string
+}
+
+// NewJournal creates a journal backed by path
+func NewJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// Default returns the journal next to the state file
+func Default() *Journal {
+	return NewJournal(platform.GetAuditFilePath())
+}
+
+// Append stamps an entry with the time and acting user, chains it to the
+// last entry and writes it as one line. The file is only ever opened for
+// appending.
+func (j *Journal) Append(entry Entry) error {
+	if entry.Time == "" {
+		entry.Time = time.Now().UTC().Format(time.RFC3339)
+	}
+	if entry.User == "" {
+		entry.User, entry.UID = actor()
+	}
+
+	key, _, err := integrity.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit journal: %w", err)
+	}
+	defer f.Close()
+
+	// Serialize appends so every entry chains to its real predecessor
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock audit journal: %w", err)
+	}
+
+	last, err := lastEntry(f)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		entry.Prev = last.MAC
+	}
+	entry.MAC = ""
+	unsealed, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	entry.MAC = key.Sign(unsealed)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit journal: %w", err)
+	}
+	return nil
+}
+
+// Read returns the entries matching filter, oldest first
+func (j *Journal) Read(filter Filter) ([]Entry, error) {
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := newScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return entries, fmt.Errorf("audit journal line %d: %w", line, err)
+		}
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// actor returns the user behind the process, looking through sudo
+func actor() (string, int) {
+	if name := os.Getenv("SUDO_USER"); name != "" {
+		uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+		if err != nil {
+			uid = -1
+		}
+		return name, uid
+	}
+
+	uid := os.Getuid()
+	if u, err := user.LookupId(strconv.Itoa(uid)); err == nil {
+		return u.Username, uid
+	}
+	return os.Getenv("USER"), uid
+}
@@ -0,0 +1,98 @@
+// Package audit keeps an append-only journal of every change Portly makes
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Action names the kind of change an entry records
+type Action string
+
+const (
+	// ActionApply is a committed firewall transaction
+	ActionApply Action = "apply"
+	// ActionAdopt records a live rule taken under management
+	ActionAdopt Action = "adopt"
+	// ActionUndo reverses the most recent change not yet undone
+	ActionUndo Action = "undo"
+	// ActionRedo re-applies the most recently undone change
+	ActionRedo Action = "redo"
+	// ActionFollow re-points a container's NAT rules after its IP changed
+	ActionFollow Action = "follow"
+	// ActionRevert undoes a change that was not confirmed in time
+	ActionRevert Action = "revert"
+	// ActionReconcile resolves drift between state and the live firewall
+	ActionReconcile Action = "reconcile"
+	// ActionRestore applies the firewall changes of a backup restore
+	ActionRestore Action = "restore"
+	// ActionSecurity changes SELinux or AppArmor from the security screens
+	ActionSecurity Action = "security"
+)
+
+// Result is the outcome of a journaled change
+type Result string
+
+const (
+	ResultSuccess    Result = "success"
+	ResultFailed     Result = "failed"
+	ResultRolledBack Result = "rolled_back"
+)
+
+// Change is one rule affected by an entry. Before and After are the state
+// records around the change; either is nil when the rule was untracked.
+type Change struct {
+	Operation *models.Operation   `json:"operation,omitempty"`
+	Before    *models.AppliedRule `json:"before,omitempty"`
+	After     *models.AppliedRule `json:"after,omitempty"`
+}
+
+// Entry is a single line of the journal
+type Entry struct {
+	Time     string                   `json:"time"`
+	User     string                   `json:"user"`
+	UID      int                      `json:"uid"`
+	Action   Action                   `json:"action"`
+	Changes  []Change                 `json:"changes"`
+	Commands []platform.CommandRecord `json:"commands,omitempty"`
+	Result   Result                   `json:"result"`
+	Error    string                   `json:"error,omitempty"`
+	// Prev is the MAC of the preceding entry and MAC seals this one,
+	// chaining the journal so edits and deletions are detectable
+	Prev string `json:"prev,omitempty"`
+	MAC  string `json:"mac,omitempty"`
+}
+
+// Filter selects journal entries. Zero fields match everything.
+type Filter struct {
+	Product string
+	Port    int
+	Since   time.Time
+	Until   time.Time
+}
+
+// String returns a one-line description of the change
+func (c Change) String() string {
+	switch {
+	case c.Operation != nil:
+		return c.Operation.String()
+	case c.After != nil:
+		return c.After.String()
+	case c.Before != nil:
+		return c.Before.String()
+	}
+	return ""
+}
+
+// Summary returns a one-line description of the entry
+func (e Entry) Summary() string {
+	changes := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, c.String())
+	}
+	return fmt.Sprintf("%s %s(%d) %s %s: %s", e.Time, e.User, e.UID, e.Action, e.Result, strings.Join(changes, "; "))
+}
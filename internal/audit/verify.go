@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/orchestrator/unified-firewall/internal/integrity"
+)
+
+// ErrTampered is returned when the journal was edited outside Portly
+var ErrTampered = errors.New("audit journal was modified outside Portly")
+
+// Verify walks the chain of sealed entries. Entries written before sealing
+// was introduced are accepted, but once a sealed entry appears every later
+// one must be sealed and chained to its predecessor.
+func (j *Journal) Verify() error {
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open audit journal: %w", err)
+	}
+	defer f.Close()
+
+	key, _, err := integrity.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	var prev string
+	sealed := false
+	scanner := newScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("%w: line %d is not valid JSON", ErrTampered, line)
+		}
+		if entry.MAC == "" {
+			if sealed {
+				return fmt.Errorf("%w: line %d is not sealed", ErrTampered, line)
+			}
+			continue
+		}
+		if sealed && entry.Prev != prev {
+			return fmt.Errorf("%w: line %d does not follow line %d", ErrTampered, line, line-1)
+		}
+
+		mac := entry.MAC
+		entry.MAC = ""
+		unsealed, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if !key.Verify(mac, unsealed) {
+			return fmt.Errorf("%w: line %d was altered", ErrTampered, line)
+		}
+		sealed = true
+		prev = mac
+	}
+	return scanner.Err()
+}
+
+// Sealed reports whether any entry carries a seal, meaning an integrity
+// key was in use before
+func (j *Journal) Sealed() (bool, error) {
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to open audit journal: %w", err)
+	}
+	defer f.Close()
+
+	scanner := newScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && entry.MAC != "" {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read audit journal: %w", err)
+	}
+	return false, nil
+}
+
+// Head returns the seal of the last entry and the number of entries, for
+// the state to record so that a journal cut short can be detected
+func (j *Journal) Head() (string, int, error) {
+	var mac string
+	count, err := j.scanHead(func(n int, entry Entry) bool {
+		mac = entry.MAC
+		return true
+	})
+	return mac, count, err
+}
+
+// CheckHead returns an error unless the journal still holds count entries,
+// the last of which carries mac. Entries appended since are accepted.
+func (j *Journal) CheckHead(mac string, count int) error {
+	var found bool
+	var got string
+	total, err := j.scanHead(func(n int, entry Entry) bool {
+		if n < count {
+			return true
+		}
+		found, got = true, entry.MAC
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %d entries left of the %d recorded", ErrTampered, total, count)
+	}
+	if got != mac {
+		return fmt.Errorf("%w: entry %d is not the one recorded", ErrTampered, count)
+	}
+	return nil
+}
+
+// scanHead calls visit with each entry and its 1-based number under a
+// shared lock, until visit returns false, and returns the entries seen.
+// Lines that are not valid JSON count as unsealed entries; Verify reports
+// them.
+func (j *Journal) scanHead(visit func(n int, entry Entry) bool) (int, error) {
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open audit journal: %w", err)
+	}
+	defer f.Close()
+
+	// Appends hold an exclusive lock, so no half-written line is seen
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_SH); err != nil {
+		return 0, fmt.Errorf("failed to lock audit journal: %w", err)
+	}
+
+	n := 0
+	scanner := newScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		n++
+		var entry Entry
+		json.Unmarshal(scanner.Bytes(), &entry)
+		if !visit(n, entry) {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return n, fmt.Errorf("failed to read audit journal: %w", err)
+	}
+	return n, nil
+}
+
+// lastEntry returns the final entry of an open journal
+func lastEntry(f *os.File) (*Entry, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var last []byte
+	scanner := newScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit journal: %w", err)
+	}
+	if last == nil {
+		return nil, nil
+	}
+	var entry Entry
+	if err := json.Unmarshal(last, &entry); err != nil {
+		return nil, fmt.Errorf("%w: last line is not valid JSON", ErrTampered)
+	}
+	return &entry, nil
+}
+
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return scanner
+}
@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Archive member names
+const (
+	manifestFile = "manifest.json"
+	stateFile    = "state.json"
+	rulesFile    = "rules.json"
+	selinuxFile  = "selinux-ports.json"
+	dumpDir      = "dump/"
+)
+
+// Write stores the archive as a gzip-compressed tarball readable only by
+// root
+func (a *Archive) Write(filePath string) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	addJSON := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return add(name, data)
+	}
+
+	if err := addJSON(manifestFile, a.Manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := add(stateFile, a.State); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := addJSON(rulesFile, a.Rules); err != nil {
+		return fmt.Errorf("failed to write rules: %w", err)
+	}
+	if err := addJSON(selinuxFile, a.SELinuxPorts); err != nil {
+		return fmt.Errorf("failed to write SELinux ports: %w", err)
+	}
+	for name, data := range a.Dumps {
+		if err := add(dumpDir+name, data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return os.Rename(tempPath, filePath)
+}
+
+// Open reads an archive written by Write
+func Open(filePath string) (*Archive, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("not a Portly backup: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	archive := &Archive{Dumps: make(map[string][]byte)}
+	var seenManifest, seenState bool
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+
+		switch name := path.Clean(hdr.Name); {
+		case name == manifestFile:
+			seenManifest = true
+			err = json.Unmarshal(data, &archive.Manifest)
+		case name == stateFile:
+			seenState = true
+			archive.State = data
+		case name == rulesFile:
+			err = json.Unmarshal(data, &archive.Rules)
+		case name == selinuxFile:
+			err = json.Unmarshal(data, &archive.SELinuxPorts)
+		case strings.HasPrefix(name, dumpDir):
+			archive.Dumps[strings.TrimPrefix(name, dumpDir)] = data
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in backup: %w", hdr.Name, err)
+		}
+	}
+
+	if !seenManifest || !seenState {
+		return nil, fmt.Errorf("backup is missing %s or %s", manifestFile, stateFile)
+	}
+	if archive.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("backup format %d is newer than supported %d", archive.Manifest.FormatVersion, FormatVersion)
+	}
+	return archive, nil
+}
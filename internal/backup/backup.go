@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+)
+
+// DefaultPath returns a timestamped archive path in the backup directory
+func DefaultPath() string {
+	name := fmt.Sprintf("portly-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	return filepath.Join(platform.GetBackupDir(), name)
+}
+
+// List returns the archives in the backup directory, newest first
+func List() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(platform.GetBackupDir(), "portly-*.tar.gz"))
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches, nil
+}
+
+// Create snapshots state, the provider's ruleset and native dump, and the
+// SELinux port labels added on this host. secMgr may be nil.
+func Create(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, secMgr *security.Manager) (*Archive, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+	if stateMgr == nil {
+		return nil, fmt.Errorf("state manager unavailable")
+	}
+
+	snapshot := stateMgr.GetState()
+	stateData, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	archive := &Archive{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+			Hostname:      hostname,
+			Provider:      provider.Name(),
+			StateVersion:  snapshot.Version,
+		},
+		State: stateData,
+		Dumps: make(map[string][]byte),
+	}
+
+	if archive.Rules.NATRules, err = provider.ListNATRules(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list NAT rules: %w", err)
+	}
+	if archive.Rules.FirewallRules, err = provider.ListFirewallRules(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	if dumper, ok := provider.(drivers.Dumper); ok {
+		if archive.Dumps, err = dumper.Dump(ctx); err != nil {
+			return nil, fmt.Errorf("failed to dump %s ruleset: %w", provider.Name(), err)
+		}
+	}
+
+	if secMgr != nil {
+		if archive.SELinuxPorts, err = secMgr.ListLocalSELinuxPorts(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive, nil
+}
+
+// Save creates a backup and writes it to path, or to DefaultPath when
+// path is empty. It returns the path written.
+func Save(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, secMgr *security.Manager, path string) (string, error) {
+	archive, err := Create(ctx, provider, stateMgr, secMgr)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		path = DefaultPath()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	return path, archive.Write(path)
+}
@@ -0,0 +1,235 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drift"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// NewPlan validates an archive and computes what restoring it would change.
+// Rules in the archive but not live are re-created; live rules tracked by
+// the current state but absent from the archive are removed. Unmanaged
+// live rules are left alone.
+func NewPlan(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, secMgr *security.Manager, archive *Archive) (*Plan, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+
+	restored, _, err := state.Migrate(archive.State)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state in backup: %w", err)
+	}
+
+	plan := &Plan{Archive: archive, State: restored}
+	if archive.Manifest.Provider != provider.Name() {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("backup was taken with %s, restoring to %s",
+			archive.Manifest.Provider, provider.Name()))
+	}
+
+	liveNAT, err := provider.ListNATRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NAT rules: %w", err)
+	}
+	liveFW, err := provider.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	for i := range archive.Rules.NATRules {
+		rule := archive.Rules.NATRules[i]
+		if !containsNAT(liveNAT, rule) {
+			rule = describeNAT(rule, restored)
+			plan.Operations = append(plan.Operations, models.Operation{Kind: models.OpApplyNAT, NAT: &rule})
+		}
+	}
+	for i := range archive.Rules.FirewallRules {
+		rule := archive.Rules.FirewallRules[i]
+		if !containsFirewall(liveFW, rule) {
+			rule = describeFirewall(rule, restored)
+			plan.Operations = append(plan.Operations, models.Operation{Kind: models.OpOpenPort, Firewall: &rule})
+		}
+	}
+
+	if stateMgr != nil {
+		for i := range liveNAT {
+			rule := liveNAT[i]
+			if stateMgr.FindNATRule(rule) != nil && !containsNAT(archive.Rules.NATRules, rule) {
+				plan.Operations = append(plan.Operations, models.Operation{Kind: models.OpRemoveNAT, NAT: &rule})
+			}
+		}
+		for i := range liveFW {
+			rule := liveFW[i]
+			if stateMgr.FindFirewallRule(rule) != nil && !containsFirewall(archive.Rules.FirewallRules, rule) {
+				plan.Operations = append(plan.Operations, models.Operation{Kind: models.OpClosePort, Firewall: &rule})
+			}
+		}
+	}
+
+	for _, op := range plan.Operations {
+		if err := validateOperation(op); err != nil {
+			return nil, fmt.Errorf("backup contains an invalid rule: %s: %w", op, err)
+		}
+	}
+
+	if secMgr != nil && len(archive.SELinuxPorts) > 0 {
+		local, err := secMgr.ListLocalSELinuxPorts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range archive.SELinuxPorts {
+			if !containsLabel(local, label) {
+				plan.Labels = append(plan.Labels, label)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Restore applies a plan: firewall changes as one transaction, then the
+// SELinux labels, then the archived state replaces the current one
+func Restore(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, secMgr *security.Manager, plan *Plan) error {
+	// State is replaced wholesale below, so the transaction records nothing
+	txn := transaction.New(provider, nil)
+	txn.SetAction(audit.ActionRestore)
+	for _, op := range plan.Operations {
+		if err := txn.Stage(op); err != nil {
+			return err
+		}
+	}
+	result, err := txn.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("restore aborted, firewall unchanged: %w", err)
+	}
+
+	if secMgr != nil {
+		for _, label := range plan.Labels {
+			if err := secMgr.AddSELinuxPort(ctx, label.Port, label.Proto, label.Type); err != nil {
+				return fmt.Errorf("firewall restored but SELinux labels incomplete: %w", err)
+			}
+		}
+	}
+
+	if stateMgr != nil {
+		recordRestored(plan.State, plan.Operations)
+		if err := stateMgr.Replace(plan.State); err != nil {
+			return fmt.Errorf("firewall restored but state was not: %w", err)
+		}
+	}
+	return result.JournalErr()
+}
+
+// describeNAT fills in the metadata backends do not keep, such as the
+// product, from the archived state record of a NAT rule. Rules the archive
+// did not track are attributed to "system".
+func describeNAT(rule models.NATRule, archived *models.State) models.NATRule {
+	for _, r := range archived.Rules {
+		if r.IsNAT() && r.Status != models.StatusRemoved && r.Matches(rule) {
+			rule.ID = r.ID
+			rule.Product = r.Product
+			rule.Description = r.Description
+			rule.Container = r.Container
+			break
+		}
+	}
+	if rule.Product == "" {
+		rule.Product = "system"
+	}
+	if rule.ID == "" {
+		rule.ID = drift.StableID(drift.Item{NAT: &rule})
+	}
+	return rule
+}
+
+// describeFirewall fills in the metadata of a firewall rule from its
+// archived state record, as describeNAT does for NAT rules
+func describeFirewall(rule models.FirewallRule, archived *models.State) models.FirewallRule {
+	for i := range archived.Rules {
+		r := &archived.Rules[i]
+		if r.IsNAT() || r.Status == models.StatusRemoved {
+			continue
+		}
+		if fw := r.FirewallRule(); fw.Matches(rule) {
+			rule.ID = r.ID
+			rule.Product = r.Product
+			rule.Description = r.Description
+			break
+		}
+	}
+	if rule.Product == "" {
+		rule.Product = "system"
+	}
+	if rule.ID == "" {
+		rule.ID = drift.StableID(drift.Item{Firewall: &rule})
+	}
+	return rule
+}
+
+// recordRestored marks the rules a restore re-created as active, adding
+// those the archived state did not track, so they are not reported as
+// drift afterwards
+func recordRestored(archived *models.State, ops []models.Operation) {
+	for _, op := range ops {
+		switch op.Kind {
+		case models.OpApplyNAT:
+			if r := archived.FindRule(op.NAT.ID); r != nil {
+				r.Status = models.StatusActive
+			} else {
+				archived.Rules = append(archived.Rules, models.NewAppliedNATRule(*op.NAT, models.StatusActive))
+			}
+		case models.OpOpenPort:
+			if r := archived.FindRule(op.Firewall.ID); r != nil {
+				r.Status = models.StatusActive
+			} else {
+				archived.Rules = append(archived.Rules, models.NewAppliedFirewallRule(*op.Firewall, models.StatusActive))
+			}
+		}
+	}
+}
+
+func validateOperation(op models.Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+	switch op.Kind {
+	case models.OpApplyNAT:
+		return op.NAT.Validate()
+	case models.OpOpenPort:
+		return op.Firewall.Validate()
+	}
+	return nil
+}
+
+func containsNAT(rules []models.NATRule, rule models.NATRule) bool {
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFirewall(rules []models.FirewallRule, rule models.FirewallRule) bool {
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLabel(labels []security.PortLabel, label security.PortLabel) bool {
+	for _, l := range labels {
+		if l.Port == label.Port && l.Proto == label.Proto && l.Type == label.Type {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// emptyProvider is a firewall with no rules
+type emptyProvider struct{}
+
+func (emptyProvider) Name() string      { return "nftables" }
+func (emptyProvider) IsAvailable() bool { return true }
+func (emptyProvider) IsProductInstalled(context.Context, string) (models.ProductInfo, error) {
+	return models.ProductInfo{}, nil
+}
+func (emptyProvider) GetInstalledProducts(context.Context) ([]models.ProductInfo, error) {
+	return nil, nil
+}
+func (emptyProvider) ApplyNAT(context.Context, models.NATRule) error { return nil }
+func (emptyProvider) RemoveNAT(context.Context, string) error        { return nil }
+func (emptyProvider) ListNATRules(context.Context) ([]models.NATRule, error) {
+	return nil, nil
+}
+func (emptyProvider) CheckConflicts(context.Context, int, models.Protocol) error { return nil }
+func (emptyProvider) OpenPort(context.Context, models.FirewallRule) error        { return nil }
+func (emptyProvider) OpenPortForIP(context.Context, models.FirewallRule) error   { return nil }
+func (emptyProvider) TrustIP(context.Context, models.FirewallRule) error         { return nil }
+func (emptyProvider) ClosePort(context.Context, string) error                    { return nil }
+func (emptyProvider) ListFirewallRules(context.Context) ([]models.FirewallRule, error) {
+	return nil, nil
+}
+func (emptyProvider) EnsureSecurityPolicy(context.Context, string, models.SecurityPolicy) error {
+	return nil
+}
+func (emptyProvider) RemoveSecurityPolicy(context.Context, string) error { return nil }
+
+func TestNewPlanRestoresNATRuleWithoutProduct(t *testing.T) {
+	tracked := models.NATRule{ID: "web", Product: "nginx", ExternalPort: 8080, InternalIP: "10.0.0.2", InternalPort: 80, Proto: models.TCP}
+	archive := &Archive{
+		Manifest: Manifest{Provider: "nftables"},
+		State:    []byte(`{"version":"1.1.0","rules":[{"id":"web","product":"nginx","external_port":8080,"internal_ip":"10.0.0.2","internal_port":80,"protocol":"tcp","type":"nat","status":"active"}]}`),
+		Rules: Ruleset{NATRules: []models.NATRule{
+			// Backends list forwards without the metadata Portly tracks
+			{ExternalPort: tracked.ExternalPort, InternalIP: tracked.InternalIP, InternalPort: tracked.InternalPort, Proto: models.TCP},
+			{ExternalPort: 2222, InternalIP: "10.0.0.3", InternalPort: 22, Proto: models.TCP},
+		}},
+	}
+
+	plan, err := NewPlan(context.Background(), emptyProvider{}, nil, nil, archive)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if len(plan.Operations) != 2 {
+		t.Fatalf("got %d operations, want 2", len(plan.Operations))
+	}
+
+	restored := plan.Operations[0].NAT
+	if restored.ID != "web" || restored.Product != "nginx" {
+		t.Errorf("tracked rule restored as %s/%s, want web/nginx", restored.ID, restored.Product)
+	}
+	untracked := plan.Operations[1].NAT
+	if untracked.Product != "system" || untracked.ID == "" {
+		t.Errorf("untracked rule restored as %q/%q, want a system rule with an ID", untracked.ID, untracked.Product)
+	}
+
+	recordRestored(plan.State, plan.Operations)
+	if r := plan.State.FindRule(untracked.ID); r == nil || r.Status != models.StatusActive {
+		t.Errorf("untracked rule not recorded as active in the restored state")
+	}
+	if len(plan.State.Rules) != 2 {
+		t.Errorf("restored state has %d rules, want 2", len(plan.State.Rules))
+	}
+}
@@ -0,0 +1,50 @@
+// Package backup snapshots everything Portly manages into a single archive
+// and restores it
+package backup
+
+import (
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// FormatVersion is the archive layout written by this build
+const FormatVersion = 1
+
+// Manifest describes an archive
+type Manifest struct {
+	FormatVersion int    `json:"format_version"`
+	CreatedAt     string `json:"created_at"`
+	Hostname      string `json:"hostname"`
+	Provider      string `json:"provider"`
+	StateVersion  string `json:"state_version"`
+}
+
+// Ruleset is the provider's listing at backup time, in driver-neutral form
+type Ruleset struct {
+	NATRules      []models.NATRule      `json:"nat_rules"`
+	FirewallRules []models.FirewallRule `json:"firewall_rules"`
+}
+
+// Archive is the content of a backup. Dumps hold the provider's native
+// export for inspection; restores work from State and Rules.
+type Archive struct {
+	Manifest     Manifest
+	State        []byte
+	Rules        Ruleset
+	SELinuxPorts []security.PortLabel
+	Dumps        map[string][]byte
+}
+
+// Plan is the validated difference between an archive and the live system
+type Plan struct {
+	Archive    *Archive
+	State      *models.State
+	Operations []models.Operation
+	Labels     []security.PortLabel
+	Warnings   []string
+}
+
+// Empty reports whether restoring would change nothing on the firewall
+func (p *Plan) Empty() bool {
+	return len(p.Operations) == 0 && len(p.Labels) == 0
+}
@@ -0,0 +1,122 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Bundle member names
+const (
+	manifestFile = "manifest.json"
+	rulesFile    = "rules.json"
+	nativeDir    = "native/"
+)
+
+// Write stores the bundle as a gzip-compressed tarball
+func (b *Bundle) Write(filePath string) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	rules, err := json.MarshalIndent(b.Rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	if err := add(manifestFile, manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := add(rulesFile, rules); err != nil {
+		return fmt.Errorf("failed to write rules: %w", err)
+	}
+	if err := add(nativeDir+b.Manifest.NativeFile, b.Native); err != nil {
+		return fmt.Errorf("failed to write %s: %w", b.Manifest.NativeFile, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return os.Rename(tempPath, filePath)
+}
+
+// Open reads a bundle written by Write
+func Open(filePath string) (*Bundle, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("not a Portly export bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+
+	bundle := &Bundle{}
+	var seenManifest, seenRules bool
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+
+		switch name := path.Clean(hdr.Name); {
+		case name == manifestFile:
+			seenManifest = true
+			err = json.Unmarshal(data, &bundle.Manifest)
+		case name == rulesFile:
+			seenRules = true
+			err = json.Unmarshal(data, &bundle.Rules)
+		case strings.HasPrefix(name, nativeDir):
+			bundle.Native = data
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in bundle: %w", hdr.Name, err)
+		}
+	}
+
+	if !seenManifest || !seenRules {
+		return nil, fmt.Errorf("bundle is missing %s or %s", manifestFile, rulesFile)
+	}
+	if bundle.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("bundle format %d is newer than supported %d", bundle.Manifest.FormatVersion, FormatVersion)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,82 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/state"
+)
+
+// DefaultPath returns a timestamped bundle path in the export directory
+func DefaultPath(format string) string {
+	name := fmt.Sprintf("portly-export-%s-%s.tar.gz", format, time.Now().UTC().Format("20060102-150405"))
+	return filepath.Join(platform.GetExportDir(), name)
+}
+
+// List returns the bundles in the export directory, newest first
+func List() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(platform.GetExportDir(), "portly-export-*.tar.gz"))
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches, nil
+}
+
+// Export renders the active managed rules for a backend, whichever backend
+// this host runs. provider may be nil.
+func Export(provider drivers.Provider, stateMgr *state.Manager, format string) (*Bundle, error) {
+	if stateMgr == nil {
+		return nil, fmt.Errorf("state manager unavailable")
+	}
+	exporter, err := drivers.NewExporter(format)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := stateMgr.ListActiveRules()
+	name, native, err := exporter.Export(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s configuration: %w", format, err)
+	}
+
+	hostname, _ := os.Hostname()
+	source := ""
+	if provider != nil {
+		source = provider.Name()
+	}
+	return &Bundle{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+			Hostname:      hostname,
+			Provider:      source,
+			Format:        format,
+			NativeFile:    name,
+		},
+		Rules:  rules,
+		Native: native,
+	}, nil
+}
+
+// Save exports a bundle and writes it to path, or to DefaultPath when path
+// is empty. It returns the path written.
+func Save(provider drivers.Provider, stateMgr *state.Manager, format, path string) (string, error) {
+	bundle, err := Export(provider, stateMgr, format)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		path = DefaultPath(format)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return path, bundle.Write(path)
+}
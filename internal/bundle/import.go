@@ -0,0 +1,90 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// NewPlan validates a bundle and computes which of its rules are missing
+// from the live firewall. Rules already present are skipped; nothing is
+// ever removed by an import.
+func NewPlan(ctx context.Context, provider drivers.Provider, b *Bundle) (*Plan, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+
+	liveNAT, err := provider.ListNATRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NAT rules: %w", err)
+	}
+	liveFW, err := provider.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	plan := &Plan{Bundle: b, Warnings: b.Warnings}
+	for i := range b.Rules {
+		rule := b.Rules[i]
+		op := rule.Operation()
+		if err := validateOperation(op); err != nil {
+			return nil, fmt.Errorf("bundle contains an invalid rule: %s: %w", op, err)
+		}
+		if (op.NAT != nil && containsNAT(liveNAT, *op.NAT)) || (op.Firewall != nil && containsFirewall(liveFW, *op.Firewall)) {
+			plan.Skipped = append(plan.Skipped, fmt.Sprintf("%s already present", op))
+			continue
+		}
+		plan.Operations = append(plan.Operations, op)
+	}
+	return plan, nil
+}
+
+// Import applies a plan as one transaction and records the rules in state
+func Import(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, plan *Plan) error {
+	txn := transaction.New(provider, stateMgr)
+	for _, op := range plan.Operations {
+		if err := txn.Stage(op); err != nil {
+			return err
+		}
+	}
+	result, err := txn.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("import aborted, firewall unchanged: %w", err)
+	}
+	return result.JournalErr()
+}
+
+func validateOperation(op models.Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+	switch op.Kind {
+	case models.OpApplyNAT:
+		return op.NAT.Validate()
+	case models.OpOpenPort:
+		return op.Firewall.Validate()
+	}
+	return nil
+}
+
+func containsNAT(rules []models.NATRule, rule models.NATRule) bool {
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFirewall(rules []models.FirewallRule, rule models.FirewallRule) bool {
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
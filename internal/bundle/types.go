@@ -0,0 +1,47 @@
+// Package bundle translates the managed ruleset into native configuration
+// for any backend and applies such bundles on another host
+package bundle
+
+import (
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// FormatVersion is the bundle layout written by this build
+const FormatVersion = 1
+
+// Formats lists the backends a bundle can be rendered for
+var Formats = []string{"nftables", "firewalld", "pf"}
+
+// Manifest describes a bundle
+type Manifest struct {
+	FormatVersion int    `json:"format_version"`
+	CreatedAt     string `json:"created_at"`
+	Hostname      string `json:"hostname"`
+	Provider      string `json:"provider"`
+	Format        string `json:"format"`
+	NativeFile    string `json:"native_file"`
+}
+
+// Bundle is an exported ruleset. Native holds the rendered configuration
+// for Manifest.Format; imports work from Rules, so a bundle can be applied
+// on a host running any backend. Warnings note what a conversion from a
+// foreign format left out and are not stored.
+type Bundle struct {
+	Manifest Manifest
+	Rules    []models.AppliedRule
+	Native   []byte
+	Warnings []string
+}
+
+// Plan is what importing a bundle would add to the live firewall
+type Plan struct {
+	Bundle     *Bundle
+	Operations []models.Operation
+	Skipped    []string
+	Warnings   []string
+}
+
+// Empty reports whether importing would change nothing
+func (p *Plan) Empty() bool {
+	return len(p.Operations) == 0
+}
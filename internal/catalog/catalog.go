@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+//go:embed defaults/*.yaml
+var defaults embed.FS
+
+// Catalog holds the known products by name
+type Catalog struct {
+	products map[string]Product
+	// Errors lists drop-in files that could not be loaded
+	Errors []error
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultCatalog *Catalog
+)
+
+// Default returns the catalog built from the embedded defaults and the
+// host's products.d directory, loaded once per process
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		defaultCatalog = Load(platform.GetProductsDir())
+	})
+	return defaultCatalog
+}
+
+// Load builds a catalog from the embedded defaults and every *.yaml file
+// in dir, in name order. Invalid files are skipped and reported in Errors.
+func Load(dir string) *Catalog {
+	c := &Catalog{products: make(map[string]Product)}
+
+	embedded, _ := defaults.ReadDir("defaults")
+	for _, entry := range embedded {
+		data, err := defaults.ReadFile("defaults/" + entry.Name())
+		if err == nil {
+			err = c.add(data)
+		}
+		if err != nil {
+			// The embedded catalog is part of the build
+			panic(fmt.Sprintf("invalid built-in product catalog %s: %v", entry.Name(), err))
+		}
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	sort.Strings(paths)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = c.add(data)
+		}
+		if err != nil {
+			c.Errors = append(c.Errors, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return c
+}
+
+// add merges the products of one file; all entries are validated first
+// so a bad file changes nothing
+func (c *Catalog) add(data []byte) error {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	for _, p := range f.Products {
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+	for _, p := range f.Products {
+		c.products[p.Name] = p
+	}
+	return nil
+}
+
+func (p Product) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("product without a name")
+	}
+	for _, port := range p.Ports {
+		if port.Port < 1 || port.Port > 65535 {
+			return fmt.Errorf("product %s: port %d out of range", p.Name, port.Port)
+		}
+		if port.Protocol != models.TCP && port.Protocol != models.UDP {
+			return fmt.Errorf("product %s: port %d needs protocol tcp or udp", p.Name, port.Port)
+		}
+	}
+	return nil
+}
+
+// Get returns a product by name
+func (c *Catalog) Get(name string) (Product, bool) {
+	p, ok := c.products[name]
+	return p, ok
+}
+
+// Names returns the product names in alphabetical order
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.products))
+	for name := range c.products {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Products returns every product in name order
+func (c *Catalog) Products() []Product {
+	var products []Product
+	for _, name := range c.Names() {
+		products = append(products, c.products[name])
+	}
+	return products
+}
@@ -0,0 +1,104 @@
+// Package catalog is the single source of product knowledge: ports,
+// packages, post-install steps and security needs. Defaults are embedded
+// and can be extended or overridden from /etc/portly/products.d.
+package catalog
+
+import (
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Port is a port a product listens on
+type Port struct {
+	Port     int             `yaml:"port"`
+	Protocol models.Protocol `yaml:"protocol"`
+	// SELinuxType overrides the product's port type for this port
+	SELinuxType string `yaml:"selinux_type,omitempty"`
+}
+
+// Security describes what a product needs from SELinux and AppArmor
+type Security struct {
+	// SELinuxPortType labels the product's ports, e.g. postgresql_port_t
+	SELinuxPortType string   `yaml:"selinux_port_type,omitempty"`
+	SELinuxBooleans []string `yaml:"selinux_booleans,omitempty"`
+	// AppArmor requests a generated AppArmor profile
+	AppArmor     bool `yaml:"apparmor,omitempty"`
+	RequiresRoot bool `yaml:"requires_root,omitempty"`
+}
+
+// Product is one catalog entry
+type Product struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// Binary is the executable that shows the product is installed;
+	// it defaults to Name
+	Binary string `yaml:"binary,omitempty"`
+	Ports  []Port `yaml:"ports,omitempty"`
+	// Packages maps a package manager (dnf, yum, apt, brew) to the
+	// package that provides the product there
+	Packages     map[string]string `yaml:"packages,omitempty"`
+	Dependencies []string          `yaml:"dependencies,omitempty"`
+	Repositories []string          `yaml:"repositories,omitempty"`
+	PostInstall  []string          `yaml:"post_install,omitempty"`
+	Security     Security          `yaml:"security,omitempty"`
+}
+
+// file is the layout of a catalog YAML file
+type file struct {
+	Products []Product `yaml:"products"`
+}
+
+// Title returns the display name, or the name when none is set
+func (p Product) Title() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
+}
+
+// BinaryName returns the executable to look for
+func (p Product) BinaryName() string {
+	if p.Binary != "" {
+		return p.Binary
+	}
+	return p.Name
+}
+
+// DefaultPorts returns the product's port numbers in catalog order,
+// without duplicates
+func (p Product) DefaultPorts() []int {
+	var ports []int
+	seen := make(map[int]bool)
+	for _, port := range p.Ports {
+		if !seen[port.Port] {
+			seen[port.Port] = true
+			ports = append(ports, port.Port)
+		}
+	}
+	return ports
+}
+
+// PackageFor returns the package to install with a package manager; yum
+// falls back to the dnf package
+func (p Product) PackageFor(manager string) string {
+	if pkg, ok := p.Packages[manager]; ok {
+		return pkg
+	}
+	if manager == "yum" {
+		return p.Packages["dnf"]
+	}
+	return ""
+}
+
+// Installable reports whether the catalog knows how to install the product
+func (p Product) Installable() bool {
+	return len(p.Packages) > 0
+}
+
+// SELinuxType returns the port type for one of the product's ports
+func (p Product) SELinuxType(port Port) string {
+	if port.SELinuxType != "" {
+		return port.SELinuxType
+	}
+	return p.Security.SELinuxPortType
+}
@@ -0,0 +1,71 @@
+// Package config reads Portly's host configuration
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// State backends
+const (
+	BackendJSON = "json"
+	BackendBolt = "bolt"
+)
+
+// Config holds host-wide settings
+type Config struct {
+	// StateBackend selects where state is stored: "json" or "bolt"
+	StateBackend string `yaml:"state_backend"`
+	// Verify probes the targets of new rules after they are applied
+	Verify bool `yaml:"verify"`
+	// ConfirmTimeout is how long a change applied over SSH stays in place
+	// without confirmation before it is reverted, e.g. "90s"
+	ConfirmTimeout time.Duration `yaml:"confirm_timeout"`
+}
+
+// DefaultConfirmTimeout is used when no confirm timeout is configured
+const DefaultConfirmTimeout = 60 * time.Second
+
+// Default returns the settings used when no config file exists
+func Default() *Config {
+	return &Config{StateBackend: BackendJSON, ConfirmTimeout: DefaultConfirmTimeout}
+}
+
+// Load reads the config file, falling back to defaults for anything not
+// set. PORTLY_STATE_BACKEND overrides the state backend and PORTLY_VERIFY=1
+// turns on verification.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(platform.GetConfigFilePath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", platform.GetConfigFilePath(), err)
+		}
+	}
+
+	if os.Getenv("PORTLY_VERIFY") == "1" {
+		cfg.Verify = true
+	}
+	if backend := os.Getenv("PORTLY_STATE_BACKEND"); backend != "" {
+		cfg.StateBackend = backend
+	}
+	if cfg.StateBackend == "" {
+		cfg.StateBackend = BackendJSON
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		return nil, fmt.Errorf("confirm_timeout must be positive, got %s", cfg.ConfirmTimeout)
+	}
+	if cfg.StateBackend != BackendJSON && cfg.StateBackend != BackendBolt {
+		return nil, fmt.Errorf("unknown state backend '%s'", cfg.StateBackend)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,181 @@
+package conflict
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/sockets"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Gather collects the live rules, listeners and SELinux labels. Listeners
+// and labels are optional: hosts without /proc or semanage are checked
+// against rules only.
+func Gather(ctx context.Context, provider drivers.Provider) (*Inventory, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+	inv := &Inventory{}
+	var err error
+	if inv.NAT, err = provider.ListNATRules(ctx); err != nil {
+		return nil, err
+	}
+	if inv.Firewall, err = provider.ListFirewallRules(ctx); err != nil {
+		return nil, err
+	}
+	inv.Listeners, _ = sockets.List()
+	if secMgr, err := security.NewManager(); err == nil {
+		inv.SELinux, _ = secMgr.ListSELinuxPortRanges(ctx)
+	}
+	return inv, nil
+}
+
+// Check gathers the inventory and checks one proposed operation
+func Check(ctx context.Context, provider drivers.Provider, op models.Operation) ([]Conflict, error) {
+	inv, err := Gather(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	return inv.Check(op), nil
+}
+
+// Check returns the conflicts of a proposed operation. Removals never
+// conflict.
+func (inv *Inventory) Check(op models.Operation) []Conflict {
+	switch {
+	case op.Kind == models.OpApplyNAT && op.NAT != nil:
+		return inv.checkNAT(*op.NAT)
+	case op.Kind == models.OpOpenPort && op.Firewall != nil:
+		if op.Firewall.Type == models.RuleTypeTrustIP {
+			return inv.checkTrust(*op.Firewall)
+		}
+		return inv.checkPort(*op.Firewall)
+	}
+	return nil
+}
+
+// checkNAT checks a forward against other forwards, open ports and
+// listeners on its external port
+func (inv *Inventory) checkNAT(rule models.NATRule) []Conflict {
+	var conflicts []Conflict
+	for _, r := range inv.NAT {
+		if r.ExternalPort != rule.ExternalPort || !sameProto(r.Proto, rule.Proto) {
+			continue
+		}
+		msg := fmt.Sprintf("port %d/%s is already forwarded to %s:%d", r.ExternalPort, r.Proto, r.InternalIP, r.InternalPort)
+		if r.Matches(rule) {
+			msg = fmt.Sprintf("the same forward already exists (%s)", describe(r.ID, r.Product))
+		}
+		conflicts = append(conflicts, Conflict{KindNAT, SeverityError, msg})
+	}
+	for _, r := range inv.Firewall {
+		if r.Type != models.RuleTypeTrustIP && r.Port == rule.ExternalPort && sameProto(r.Protocol, rule.Proto) {
+			conflicts = append(conflicts, Conflict{KindOpenPort, SeverityWarning,
+				fmt.Sprintf("port %d/%s is also opened for this host (%s); forwarded traffic will not reach a local service", r.Port, r.Protocol, describe(r.ID, r.Product))})
+		}
+	}
+	for _, l := range inv.Listeners {
+		if l.Port == rule.ExternalPort && sameProto(l.Protocol, rule.Proto) && !l.Loopback() {
+			conflicts = append(conflicts, Conflict{KindListener, SeverityWarning,
+				fmt.Sprintf("%s listens on this port; the forward will take its incoming traffic", l.String())})
+		}
+	}
+	return conflicts
+}
+
+// checkPort checks an opened or source-limited port against other rules
+// for the port, forwards, trusted sources and SELinux labels
+func (inv *Inventory) checkPort(rule models.FirewallRule) []Conflict {
+	var conflicts []Conflict
+	for _, r := range inv.Firewall {
+		switch {
+		case r.Type == models.RuleTypeTrustIP:
+			if rule.Type == models.RuleTypePortLimit && r.SourceIP == rule.SourceIP {
+				conflicts = append(conflicts, Conflict{KindTrustedIP, SeverityWarning,
+					fmt.Sprintf("%s is already trusted on every port (%s)", r.SourceIP, describe(r.ID, r.Product))})
+			}
+		case r.Port != rule.Port || !sameProto(r.Protocol, rule.Protocol):
+			// Rules for other ports do not overlap
+		case r.Type == models.RuleTypePort && rule.Type == models.RuleTypePort:
+			conflicts = append(conflicts, Conflict{KindOpenPort, SeverityError,
+				fmt.Sprintf("port %d/%s is already open (%s)", r.Port, r.Protocol, describe(r.ID, r.Product))})
+		case r.Type == models.RuleTypePort:
+			conflicts = append(conflicts, Conflict{KindOpenPort, SeverityWarning,
+				fmt.Sprintf("port %d/%s is already open to everyone (%s); limiting it to %s has no effect", r.Port, r.Protocol, describe(r.ID, r.Product), rule.SourceIP)})
+		case rule.Type == models.RuleTypePort:
+			conflicts = append(conflicts, Conflict{KindOpenPort, SeverityWarning,
+				fmt.Sprintf("port %d/%s is limited to %s (%s); this opens it to everyone", r.Port, r.Protocol, r.SourceIP, describe(r.ID, r.Product))})
+		case r.SourceIP == rule.SourceIP:
+			conflicts = append(conflicts, Conflict{KindOpenPort, SeverityError,
+				fmt.Sprintf("port %d/%s is already open for %s (%s)", r.Port, r.Protocol, r.SourceIP, describe(r.ID, r.Product))})
+		}
+	}
+	for _, r := range inv.NAT {
+		if r.ExternalPort == rule.Port && sameProto(r.Proto, rule.Protocol) {
+			conflicts = append(conflicts, Conflict{KindNAT, SeverityWarning,
+				fmt.Sprintf("port %d/%s is forwarded to %s:%d; a local service will not receive its traffic", r.ExternalPort, r.Proto, r.InternalIP, r.InternalPort)})
+		}
+	}
+	if c, ok := inv.checkLabel(rule); ok {
+		conflicts = append(conflicts, c)
+	}
+	return conflicts
+}
+
+// checkLabel compares the port's SELinux type with what the rule's
+// product needs
+func (inv *Inventory) checkLabel(rule models.FirewallRule) (Conflict, bool) {
+	if len(inv.SELinux) == 0 || rule.Product == "" {
+		return Conflict{}, false
+	}
+	product, ok := catalog.Default().Get(rule.Product)
+	if !ok {
+		return Conflict{}, false
+	}
+	want := product.Security.SELinuxPortType
+	for _, p := range product.Ports {
+		if p.Port == rule.Port && sameProto(p.Protocol, rule.Protocol) {
+			want = product.SELinuxType(p)
+		}
+	}
+	have := security.SELinuxPortType(inv.SELinux, rule.Port, rule.Protocol)
+	if want == "" || have == "" || have == want {
+		return Conflict{}, false
+	}
+	return Conflict{KindSELinux, SeverityWarning,
+		fmt.Sprintf("port %d/%s is labeled %s but %s needs %s", rule.Port, rule.Protocol, have, product.Title(), want)}, true
+}
+
+// checkTrust checks a trusted source against other rules for that source
+func (inv *Inventory) checkTrust(rule models.FirewallRule) []Conflict {
+	var conflicts []Conflict
+	for _, r := range inv.Firewall {
+		if r.SourceIP != rule.SourceIP {
+			continue
+		}
+		if r.Type == models.RuleTypeTrustIP {
+			conflicts = append(conflicts, Conflict{KindTrustedIP, SeverityError,
+				fmt.Sprintf("%s is already trusted (%s)", r.SourceIP, describe(r.ID, r.Product))})
+		} else if r.Type == models.RuleTypePortLimit {
+			conflicts = append(conflicts, Conflict{KindTrustedIP, SeverityWarning,
+				fmt.Sprintf("port %d/%s limited to %s becomes redundant (%s)", r.Port, r.Protocol, r.SourceIP, describe(r.ID, r.Product))})
+		}
+	}
+	return conflicts
+}
+
+// describe names a rule by ID and product
+func describe(id, product string) string {
+	if product == "" {
+		return "rule " + id
+	}
+	return fmt.Sprintf("rule %s, %s", id, product)
+}
+
+func sameProto(a, b models.Protocol) bool {
+	return strings.EqualFold(string(a), string(b))
+}
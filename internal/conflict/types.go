@@ -0,0 +1,67 @@
+// Package conflict checks a proposed rule against everything else that
+// claims its port or source: NAT forwards, firewall rules, local listeners
+// and SELinux port labels
+package conflict
+
+import (
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/sockets"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Kind names what the proposed rule conflicts with
+type Kind string
+
+const (
+	// KindNAT is an existing forward of the same external port
+	KindNAT Kind = "nat"
+	// KindOpenPort is a firewall rule for the same port
+	KindOpenPort Kind = "open_port"
+	// KindTrustedIP is a trusted source the rule overlaps with
+	KindTrustedIP Kind = "trusted_ip"
+	// KindListener is a local service on the same port
+	KindListener Kind = "listener"
+	// KindSELinux is a port labeled for another service
+	KindSELinux Kind = "selinux"
+)
+
+// Severity tells whether a conflict blocks the rule
+type Severity string
+
+const (
+	// SeverityError is a rule that duplicates or contradicts another one
+	SeverityError Severity = "error"
+	// SeverityWarning is a rule that works but probably not as intended
+	SeverityWarning Severity = "warning"
+)
+
+// Conflict is one problem with a proposed rule
+type Conflict struct {
+	Kind     Kind
+	Severity Severity
+	Message  string
+}
+
+// String returns a human-readable representation of the conflict
+func (c Conflict) String() string {
+	return string(c.Severity) + ": " + c.Message
+}
+
+// HasErrors reports whether any conflict blocks the rule
+func HasErrors(conflicts []Conflict) bool {
+	for _, c := range conflicts {
+		if c.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Inventory is what proposed rules are checked against
+type Inventory struct {
+	NAT       []models.NATRule
+	Firewall  []models.FirewallRule
+	Listeners []sockets.Listener
+	// SELinux is empty where SELinux is not in use
+	SELinux []security.PortRange
+}
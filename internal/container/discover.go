@@ -0,0 +1,62 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// List returns the running containers of every installed runtime. Errors
+// from one runtime (e.g. a stopped docker daemon) are only returned when
+// no containers could be listed at all.
+func List(ctx context.Context) ([]Container, error) {
+	var containers []Container
+	var errs []error
+	found := false
+	for _, rt := range Runtimes {
+		if !platform.CommandExists(string(rt)) {
+			continue
+		}
+		found = true
+		list, err := listRuntime(ctx, rt)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		containers = append(containers, list...)
+	}
+
+	if !found {
+		return nil, ErrNoRuntime
+	}
+	if len(containers) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// listRuntime inspects the running containers of one runtime
+func listRuntime(ctx context.Context, rt Runtime) ([]Container, error) {
+	cmd := exec.CommandContext(ctx, string(rt), "ps", "-q", "--no-trunc")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s containers: %w (output: %s)", rt, err, string(output))
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cmd = exec.CommandContext(ctx, string(rt), append([]string{"inspect"}, ids...)...)
+	output, err = cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect %s containers: %w (output: %s)", rt, err, string(output))
+	}
+	return parseInspect(rt, output)
+}
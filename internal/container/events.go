@@ -0,0 +1,111 @@
+package container
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sync"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// Event is a container lifecycle event
+type Event struct {
+	Runtime Runtime
+	ID      string
+	Name    string
+	Action  string
+}
+
+// eventLine holds the fields of `events --format '{{json .}}'` output;
+// podman uses ID/Name/Status, docker id/Actor/Action
+type eventLine struct {
+	ID     string `json:"ID"`
+	Name   string `json:"Name"`
+	Status string `json:"Status"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+}
+
+// Events streams container start events from every installed runtime
+// until ctx is cancelled. The channel is closed once all streams end. If a
+// runtime's stream cannot be started, the streams already running are
+// stopped before the error is returned.
+func Events(ctx context.Context) (<-chan Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan Event)
+	var wg sync.WaitGroup
+	found := false
+	for _, rt := range Runtimes {
+		if !platform.CommandExists(string(rt)) {
+			continue
+		}
+		found = true
+		cmd := exec.CommandContext(ctx, string(rt), "events",
+			"--filter", "type=container", "--filter", "event=start",
+			"--format", "{{json .}}")
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to watch %s events: %w", rt, err)
+		}
+		if err := cmd.Start(); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to watch %s events: %w", rt, err)
+		}
+
+		wg.Add(1)
+		go func(rt Runtime) {
+			defer wg.Done()
+			defer cmd.Wait()
+			scanner := bufio.NewScanner(stdout)
+			for scanner.Scan() {
+				event, ok := parseEvent(rt, scanner.Bytes())
+				if !ok {
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(rt)
+	}
+
+	if !found {
+		cancel()
+		return nil, ErrNoRuntime
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(events)
+	}()
+	return events, nil
+}
+
+// parseEvent converts one line of event output
+func parseEvent(rt Runtime, line []byte) (Event, bool) {
+	var e eventLine
+	if err := json.Unmarshal(line, &e); err != nil {
+		return Event{}, false
+	}
+	event := Event{Runtime: rt, ID: e.ID, Name: e.Name, Action: e.Status}
+	if event.ID == "" {
+		event.ID = e.Actor.ID
+	}
+	if event.Name == "" {
+		event.Name = e.Actor.Attributes["name"]
+	}
+	if event.Action == "" {
+		event.Action = e.Action
+	}
+	return event, event.Name != "" || event.ID != ""
+}
@@ -0,0 +1,96 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// inspectEntry holds the fields of `inspect` output that podman and docker
+// share
+type inspectEntry struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Config struct {
+		Image        string                     `json:"Image"`
+		ExposedPorts map[string]json.RawMessage `json:"ExposedPorts"`
+	} `json:"Config"`
+	NetworkSettings struct {
+		IPAddress string                     `json:"IPAddress"`
+		Ports     map[string]json.RawMessage `json:"Ports"`
+		Networks  map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// parseInspect converts `inspect` output into containers
+func parseInspect(rt Runtime, data []byte) ([]Container, error) {
+	var entries []inspectEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s inspect output: %w", rt, err)
+	}
+
+	containers := make([]Container, 0, len(entries))
+	for _, e := range entries {
+		c := Container{
+			ID:      e.ID,
+			Name:    strings.TrimPrefix(e.Name, "/"),
+			Image:   e.Config.Image,
+			Runtime: rt,
+		}
+
+		names := make([]string, 0, len(e.NetworkSettings.Networks))
+		for name := range e.NetworkSettings.Networks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			c.Networks = append(c.Networks, Network{Name: name, IP: e.NetworkSettings.Networks[name].IPAddress})
+		}
+		if len(c.Networks) == 0 && e.NetworkSettings.IPAddress != "" {
+			c.Networks = append(c.Networks, Network{Name: "default", IP: e.NetworkSettings.IPAddress})
+		}
+
+		c.Ports = parsePorts(e.Config.ExposedPorts, e.NetworkSettings.Ports)
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// parsePorts merges "80/tcp" style keys from the exposed and published
+// port maps
+func parsePorts(maps ...map[string]json.RawMessage) []Port {
+	seen := make(map[Port]bool)
+	var ports []Port
+	for _, m := range maps {
+		for key := range m {
+			number, proto, _ := strings.Cut(key, "/")
+			port, err := strconv.Atoi(number)
+			if err != nil || port < 1 || port > 65535 {
+				continue
+			}
+			p := Port{Port: port, Protocol: models.TCP}
+			if proto == "udp" {
+				p.Protocol = models.UDP
+			} else if proto != "" && proto != "tcp" {
+				continue
+			}
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports
+}
@@ -0,0 +1,106 @@
+// Package container discovers running podman and docker containers so
+// that NAT rules can target them by name
+package container
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Runtime is a container engine
+type Runtime string
+
+const (
+	RuntimePodman Runtime = "podman"
+	RuntimeDocker Runtime = "docker"
+)
+
+// Runtimes lists the engines queried, in order
+var Runtimes = []Runtime{RuntimePodman, RuntimeDocker}
+
+// ErrNoRuntime is returned when neither podman nor docker is installed
+var ErrNoRuntime = errors.New("no container runtime found (podman or docker)")
+
+// Network is a network a container is attached to
+type Network struct {
+	Name string
+	IP   string
+}
+
+// Port is a port a container exposes
+type Port struct {
+	Port     int
+	Protocol models.Protocol
+}
+
+// Container is a running container
+type Container struct {
+	ID       string
+	Name     string
+	Image    string
+	Runtime  Runtime
+	Networks []Network
+	Ports    []Port
+}
+
+// IP returns the container's address on its first network with one
+func (c *Container) IP() string {
+	for _, n := range c.Networks {
+		if n.IP != "" {
+			return n.IP
+		}
+	}
+	return ""
+}
+
+// Target is a container port that a NAT rule can forward to
+type Target struct {
+	Container string
+	Runtime   Runtime
+	IP        string
+	Port      int
+	Protocol  models.Protocol
+}
+
+// String returns a human-readable representation of the target
+func (t Target) String() string {
+	if t.Port == 0 {
+		return fmt.Sprintf("%s (%s) %s", t.Container, t.Runtime, t.IP)
+	}
+	return fmt.Sprintf("%s (%s) %s:%d/%s", t.Container, t.Runtime, t.IP, t.Port, t.Protocol)
+}
+
+// Apply points a NAT rule at the target and tags it with the container
+func (t Target) Apply(rule *models.NATRule) {
+	rule.InternalIP = t.IP
+	rule.Container = t.Container
+	if t.Port > 0 {
+		rule.InternalPort = t.Port
+		rule.Proto = t.Protocol
+	}
+}
+
+// Targets returns one target per exposed port, or a single target without
+// a port when the container exposes none
+func (c *Container) Targets() []Target {
+	ip := c.IP()
+	if ip == "" {
+		return nil
+	}
+	if len(c.Ports) == 0 {
+		return []Target{{Container: c.Name, Runtime: c.Runtime, IP: ip}}
+	}
+	targets := make([]Target, 0, len(c.Ports))
+	for _, p := range c.Ports {
+		targets = append(targets, Target{
+			Container: c.Name,
+			Runtime:   c.Runtime,
+			IP:        ip,
+			Port:      p.Port,
+			Protocol:  p.Protocol,
+		})
+	}
+	return targets
+}
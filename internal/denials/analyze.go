@@ -0,0 +1,165 @@
+package denials
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// connectBooleans let common domains connect to any port, for products
+// whose catalog entry lists no booleans
+var connectBooleans = map[string]string{
+	"httpd_t":        "httpd_can_network_connect",
+	"haproxy_t":      "haproxy_connect_any",
+	"squid_t":        "squid_connect_any",
+	"container_t":    "container_connect_any",
+	"ftpd_t":         "ftpd_connect_all_unreserved",
+	"mysqld_t":       "mysql_connect_any",
+	"zabbix_t":       "zabbix_can_network",
+	"zabbix_agent_t": "zabbix_can_network",
+}
+
+// databasePortTypes are the port types httpd_can_network_connect_db covers
+var databasePortTypes = []string{
+	"postgresql_port_t", "mysqld_port_t", "mssql_port_t", "oracle_port_t", "mongod_port_t",
+}
+
+// Build reads the audit log and joins its denials with the tracked rules
+// and port labels
+func Build(ctx context.Context, secMgr *security.Manager, stateMgr *state.Manager) (*Report, error) {
+	denials, err := secMgr.ListAVCDenials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rules []models.AppliedRule
+	var labels []models.PortLabelRecord
+	if stateMgr != nil {
+		rules = stateMgr.ListActiveRules()
+		labels = stateMgr.ListPortLabels()
+	}
+	booleanOn := func(name string) bool {
+		on, err := secMgr.GetSELinuxBoolean(ctx, name)
+		return err == nil && on
+	}
+	return Analyze(denials, rules, labels, booleanOn), nil
+}
+
+// Analyze groups denials on managed ports by product and suggests fixes.
+// booleanOn reports whether a boolean is already on, so that it is not
+// suggested again.
+func Analyze(denials []security.AVCDenial, rules []models.AppliedRule, labels []models.PortLabelRecord, booleanOn func(string) bool) *Report {
+	owners := managedPorts(rules, labels)
+	report := &Report{}
+	byProduct := make(map[string]*Summary)
+
+	for _, denial := range denials {
+		product, ok := owners[portKey(denial.Port, denial.Proto)]
+		if !ok {
+			report.Unmanaged += denial.Count
+			continue
+		}
+		summary := byProduct[product]
+		if summary == nil {
+			summary = &Summary{Product: product}
+			byProduct[product] = summary
+		}
+		summary.Denials = append(summary.Denials, denial)
+		for _, fix := range suggest(denial, product, booleanOn) {
+			if !slices.Contains(summary.Fixes, fix) {
+				summary.Fixes = append(summary.Fixes, fix)
+			}
+		}
+	}
+
+	for _, summary := range byProduct {
+		report.Products = append(report.Products, *summary)
+	}
+	sort.Slice(report.Products, func(i, j int) bool {
+		return report.Products[i].Product < report.Products[j].Product
+	})
+	return report
+}
+
+// managedPorts maps the ports of active rules and owned labels to their
+// product. NAT rules contribute both ends, since the service may be
+// denied binding its port or a proxy denied connecting to it.
+func managedPorts(rules []models.AppliedRule, labels []models.PortLabelRecord) map[string]string {
+	owners := make(map[string]string)
+	claim := func(port int, proto models.Protocol, product string) {
+		if port == 0 {
+			return
+		}
+		key := portKey(port, proto)
+		if owners[key] == "" {
+			owners[key] = product
+		}
+	}
+	for _, r := range rules {
+		if r.IsNAT() {
+			claim(r.ExternalPort, r.Proto, r.Product)
+			claim(r.InternalPort, r.Proto, r.Product)
+		} else if r.RuleType() != models.RuleTypeTrustIP {
+			claim(r.Port, r.Proto, r.Product)
+		}
+	}
+	for _, label := range labels {
+		claim(label.Port, label.Proto, label.Product)
+	}
+	return owners
+}
+
+// suggest returns the fixes for one denial: a product's booleans, or a
+// well-known one, for connects; a label of the product's port type for
+// binds and for connects no boolean covers
+func suggest(denial security.AVCDenial, product string, booleanOn func(string) bool) []Fix {
+	entry, known := catalog.Default().Get(product)
+
+	var fixes []Fix
+	if denial.Permission == "name_connect" {
+		var booleans []string
+		if known {
+			booleans = entry.Security.SELinuxBooleans
+		}
+		if len(booleans) == 0 {
+			if b := connectBoolean(denial); b != "" {
+				booleans = []string{b}
+			}
+		}
+		for _, b := range booleans {
+			if !booleanOn(b) {
+				fixes = append(fixes, Fix{Kind: FixBoolean, Product: product, Boolean: b})
+			}
+		}
+		if len(fixes) > 0 {
+			return fixes
+		}
+	}
+
+	if !known {
+		return fixes
+	}
+	selinuxType := security.ProductPortType(entry, catalog.Port{Port: denial.Port, Protocol: denial.Proto})
+	if selinuxType != "" && selinuxType != denial.TargetType {
+		fixes = append(fixes, Fix{Kind: FixPortLabel, Product: product, Port: denial.Port, Proto: denial.Proto, Type: selinuxType})
+	}
+	return fixes
+}
+
+// connectBoolean returns the boolean letting a domain make a denied connect
+func connectBoolean(denial security.AVCDenial) string {
+	if denial.SourceType == "httpd_t" && slices.Contains(databasePortTypes, denial.TargetType) {
+		return "httpd_can_network_connect_db"
+	}
+	return connectBooleans[denial.SourceType]
+}
+
+func portKey(port int, proto models.Protocol) string {
+	return fmt.Sprintf("%d/%s", port, strings.ToLower(string(proto)))
+}
@@ -0,0 +1,16 @@
+package denials
+
+import (
+	"context"
+
+	"github.com/orchestrator/unified-firewall/internal/security"
+)
+
+// Apply makes the change through the security manager, which records it
+// so it is reverted with the product's rules
+func (f Fix) Apply(ctx context.Context, secMgr *security.Manager) error {
+	if f.Kind == FixBoolean {
+		return secMgr.EnableProductBoolean(ctx, f.Product, f.Boolean)
+	}
+	return secMgr.LabelPort(ctx, f.Product, f.Port, f.Proto, f.Type)
+}
@@ -0,0 +1,67 @@
+// Package denials ties SELinux AVC denials on ports to the rules and
+// products Portly manages and suggests fixes it can apply
+package denials
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// FixKind is the kind of change a fix makes
+type FixKind string
+
+const (
+	// FixBoolean turns on an SELinux boolean
+	FixBoolean FixKind = "boolean"
+	// FixPortLabel labels a port with a type the service may use
+	FixPortLabel FixKind = "port_label"
+)
+
+// Fix is a suggested change that would stop a denial
+type Fix struct {
+	Kind    FixKind
+	Product string
+	Boolean string
+	Port    int
+	Proto   models.Protocol
+	Type    string
+}
+
+// String returns the command the fix amounts to
+func (f Fix) String() string {
+	if f.Kind == FixBoolean {
+		return fmt.Sprintf("setsebool -P %s on", f.Boolean)
+	}
+	return fmt.Sprintf("semanage port -a -t %s -p %s %d", f.Type, f.Proto, f.Port)
+}
+
+// Summary groups the denials on one product's ports
+type Summary struct {
+	// Product is empty for managed ports whose rules name no product
+	Product string
+	Denials []security.AVCDenial
+	Fixes   []Fix
+}
+
+// Report lists denials on managed ports per product
+type Report struct {
+	Products []Summary
+	// Unmanaged counts denials on ports Portly does not manage
+	Unmanaged int
+}
+
+// Fixes returns every suggested fix in the report
+func (r *Report) Fixes() []Fix {
+	var fixes []Fix
+	for _, s := range r.Products {
+		fixes = append(fixes, s.Fixes...)
+	}
+	return fixes
+}
+
+// Empty returns true if no managed port was denied
+func (r *Report) Empty() bool {
+	return len(r.Products) == 0
+}
@@ -0,0 +1,117 @@
+package drift
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Unmanaged returns the live rules that Portly does not track
+func (r *Report) Unmanaged() []Item {
+	var items []Item
+	for _, item := range r.Items {
+		if item.Kind == KindExtra {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// StableID derives a state ID from a live rule's content, so adopting the
+// same rule twice, or on another host, yields the same ID
+func StableID(item Item) string {
+	var key string
+	switch {
+	case item.NAT != nil:
+		key = fmt.Sprintf("nat|%d|%s|%s|%d", item.NAT.ExternalPort,
+			strings.ToLower(string(item.NAT.Proto)), item.NAT.InternalIP, item.NAT.InternalPort)
+	case item.Firewall != nil:
+		rule := models.NewAppliedFirewallRule(*item.Firewall, models.StatusActive)
+		key = fmt.Sprintf("fw|%s|%d|%s|%s", rule.Type, rule.Port,
+			strings.ToLower(string(rule.Proto)), rule.SourceIP)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "adopt-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// Adopt records a live rule in state so Portly manages it from now on. For
+// a modified item the tracked record is updated to the live target. Empty
+// product and description keep whatever the backend reported.
+func Adopt(stateMgr *state.Manager, item Item, product, description string) (*models.AppliedRule, error) {
+	if stateMgr == nil {
+		return nil, fmt.Errorf("state manager unavailable")
+	}
+
+	var record models.AppliedRule
+	switch {
+	case item.NAT != nil:
+		record = models.NewAppliedNATRule(*item.NAT, models.StatusActive)
+	case item.Firewall != nil:
+		record = models.NewAppliedFirewallRule(*item.Firewall, models.StatusActive)
+	default:
+		return nil, fmt.Errorf("drift item has no live rule to adopt")
+	}
+
+	tracked := item.Tracked
+	if tracked == nil {
+		record.ID = StableID(item)
+		tracked = stateMgr.GetRule(record.ID)
+	}
+	if tracked != nil {
+		record.ID = tracked.ID
+		record.Product = tracked.Product
+		record.Description = tracked.Description
+		record.AppliedAt = tracked.AppliedAt
+	}
+
+	if product != "" {
+		record.Product = product
+	}
+	if record.Product == "" {
+		record.Product = "system"
+	}
+	if description != "" {
+		record.Description = description
+	}
+
+	var before *models.AppliedRule
+	var err error
+	if tracked != nil {
+		previous := *tracked
+		before = &previous
+		err = stateMgr.UpdateRule(record)
+	} else {
+		err = stateMgr.AddRule(record)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry := audit.Entry{
+		Action:  audit.ActionAdopt,
+		Changes: []audit.Change{{Before: before, After: &record}},
+		Result:  audit.ResultSuccess,
+	}
+	if err := audit.Default().Append(entry); err != nil {
+		return &record, fmt.Errorf("rule adopted but not journaled: %w", err)
+	}
+	return &record, nil
+}
+
+// AdoptAll adopts every item with the same product and description
+func AdoptAll(stateMgr *state.Manager, items []Item, product, description string) ([]models.AppliedRule, error) {
+	adopted := make([]models.AppliedRule, 0, len(items))
+	for _, item := range items {
+		record, err := Adopt(stateMgr, item, product, description)
+		if err != nil {
+			return adopted, fmt.Errorf("failed to adopt %s: %w", item.String(), err)
+		}
+		adopted = append(adopted, *record)
+	}
+	return adopted, nil
+}
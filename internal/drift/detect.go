@@ -0,0 +1,91 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Detect compares the active rules in state with the provider's live
+// ruleset
+func Detect(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager) (*Report, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+	if stateMgr == nil {
+		return nil, fmt.Errorf("state manager unavailable")
+	}
+
+	liveNAT, err := provider.ListNATRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NAT rules: %w", err)
+	}
+	liveFW, err := provider.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	report := &Report{}
+	usedNAT := make([]bool, len(liveNAT))
+	usedFW := make([]bool, len(liveFW))
+
+	for _, tracked := range stateMgr.ListActiveRules() {
+		tracked := tracked
+		if tracked.IsNAT() {
+			report.addNAT(&tracked, liveNAT, usedNAT)
+		} else {
+			report.addFirewall(&tracked, liveFW, usedFW)
+		}
+	}
+
+	for i := range liveNAT {
+		if !usedNAT[i] {
+			report.Items = append(report.Items, Item{Kind: KindExtra, NAT: &liveNAT[i]})
+		}
+	}
+	for i := range liveFW {
+		if !usedFW[i] {
+			report.Items = append(report.Items, Item{Kind: KindExtra, Firewall: &liveFW[i]})
+		}
+	}
+
+	return report, nil
+}
+
+// addNAT matches a tracked NAT rule against the live forwards. A forward on
+// the same external port with a different target counts as modified.
+func (r *Report) addNAT(tracked *models.AppliedRule, live []models.NATRule, used []bool) {
+	for i := range live {
+		if !used[i] && live[i].Matches(tracked.NATRule) {
+			used[i] = true
+			return
+		}
+	}
+	for i := range live {
+		if !used[i] && live[i].ExternalPort == tracked.ExternalPort &&
+			strings.EqualFold(string(live[i].Proto), string(tracked.Proto)) {
+			used[i] = true
+			r.Items = append(r.Items, Item{Kind: KindModified, Tracked: tracked, NAT: &live[i]})
+			return
+		}
+	}
+	r.Items = append(r.Items, Item{Kind: KindMissing, Tracked: tracked})
+}
+
+// addFirewall matches a tracked firewall rule against the live rules.
+// Several rules may share a port, so firewall rules are never reported as
+// modified; a changed rule shows up as one missing and one extra item.
+func (r *Report) addFirewall(tracked *models.AppliedRule, live []models.FirewallRule, used []bool) {
+	want := tracked.FirewallRule()
+	for i := range live {
+		if !used[i] && live[i].Matches(want) {
+			used[i] = true
+			return
+		}
+	}
+	r.Items = append(r.Items, Item{Kind: KindMissing, Tracked: tracked})
+}
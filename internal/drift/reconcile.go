@@ -0,0 +1,75 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+)
+
+// Reconcile resolves a drift report according to policy. All firewall
+// changes are applied as one transaction; adopted rules are recorded in
+// state once it commits.
+func Reconcile(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, report *Report, policy Policy) (*transaction.Result, error) {
+	txn := transaction.New(provider, stateMgr)
+	txn.SetAction(audit.ActionReconcile)
+	var adopt []Item
+
+	for _, item := range report.Items {
+		switch item.Kind {
+		case KindMissing:
+			if policy.Restore {
+				if err := txn.Stage(item.Tracked.Operation()); err != nil {
+					return nil, err
+				}
+			}
+		case KindModified:
+			if policy.Restore {
+				if err := txn.RemoveNAT(*item.NAT); err != nil {
+					return nil, err
+				}
+				if err := txn.Stage(item.Tracked.Operation()); err != nil {
+					return nil, err
+				}
+			} else if policy.Unmanaged == UnmanagedAdopt {
+				adopt = append(adopt, item)
+			}
+		case KindExtra:
+			if policy.Unmanaged == UnmanagedAdopt {
+				adopt = append(adopt, item)
+			}
+		}
+	}
+
+	result, err := txn.Commit(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range adopt {
+		if _, err := Adopt(stateMgr, item, "", ""); err != nil {
+			return result, err
+		}
+	}
+	return result, result.JournalErr()
+}
+
+// Removal stages the removal of the selected unmanaged rules from the
+// firewall as one transaction, for the caller to commit
+func Removal(provider drivers.Provider, stateMgr *state.Manager, items []Item) (*transaction.Transaction, error) {
+	txn := transaction.New(provider, stateMgr)
+	txn.SetAction(audit.ActionReconcile)
+	for _, item := range items {
+		op, ok := item.LiveOperation()
+		if item.Kind != KindExtra || !ok {
+			return nil, fmt.Errorf("%s is not an unmanaged rule", item.String())
+		}
+		if err := txn.Stage(op.Inverse()); err != nil {
+			return nil, err
+		}
+	}
+	return txn, nil
+}
@@ -0,0 +1,94 @@
+// Package drift compares tracked state with the live firewall ruleset
+package drift
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Kind classifies a difference between state and the live firewall
+type Kind string
+
+const (
+	// KindMissing is a tracked active rule absent from the firewall
+	KindMissing Kind = "missing"
+	// KindExtra is a live rule Portly does not track
+	KindExtra Kind = "extra"
+	// KindModified is a tracked NAT rule whose external port now forwards
+	// to a different target
+	KindModified Kind = "modified"
+)
+
+// Item is a single difference. Tracked is set for missing and modified
+// items; NAT or Firewall holds the live rule for extra and modified ones.
+type Item struct {
+	Kind     Kind
+	Tracked  *models.AppliedRule
+	NAT      *models.NATRule
+	Firewall *models.FirewallRule
+}
+
+// Report lists every difference found by Detect
+type Report struct {
+	Items []Item
+}
+
+// UnmanagedAction decides what Reconcile does with extra rules. Removing
+// them is left to Removal, for rules the user picked one by one.
+type UnmanagedAction string
+
+const (
+	UnmanagedIgnore UnmanagedAction = "ignore"
+	UnmanagedAdopt  UnmanagedAction = "adopt"
+)
+
+// Policy controls how Reconcile resolves a report
+type Policy struct {
+	// Restore re-applies missing rules and restores modified ones
+	Restore   bool
+	Unmanaged UnmanagedAction
+}
+
+// LiveOperation returns the operation that creates the item's live rule
+func (i Item) LiveOperation() (models.Operation, bool) {
+	if i.NAT != nil {
+		return models.Operation{Kind: models.OpApplyNAT, NAT: i.NAT}, true
+	}
+	if i.Firewall != nil {
+		return models.Operation{Kind: models.OpOpenPort, Firewall: i.Firewall}, true
+	}
+	return models.Operation{}, false
+}
+
+// String returns a human-readable description of the item
+func (i Item) String() string {
+	live := ""
+	if op, ok := i.LiveOperation(); ok {
+		live = op.String()
+	}
+	switch i.Kind {
+	case KindMissing:
+		return fmt.Sprintf("missing: %s", i.Tracked.String())
+	case KindModified:
+		return fmt.Sprintf("modified: %s (live: %s)", i.Tracked.String(), live)
+	default:
+		return fmt.Sprintf("extra: %s", live)
+	}
+}
+
+// Count returns the number of items of a kind
+func (r *Report) Count(kind Kind) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// InSync reports whether state and the firewall agree
+func (r *Report) InSync() bool {
+	return len(r.Items) == 0
+}
@@ -15,4 +15,6 @@ var (
 	ErrPermissionDenied     = errors.ErrPermissionDenied
 	ErrSecurityPolicyFailed = errors.ErrSecurityPolicyFailed
 	ErrUnsupportedProduct   = errors.ErrUnsupportedProduct
+	ErrSSHLockout           = errors.ErrSSHLockout
+	ErrPendingChange        = errors.ErrPendingChange
 )
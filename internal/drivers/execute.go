@@ -0,0 +1,54 @@
+package drivers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Execute applies a single operation through the provider. Removals are
+// resolved against the provider's own listing so that rules can be removed
+// by content rather than by backend-specific ID.
+func Execute(ctx context.Context, p Provider, op models.Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+
+	switch op.Kind {
+	case models.OpApplyNAT:
+		return p.ApplyNAT(ctx, *op.NAT)
+
+	case models.OpRemoveNAT:
+		existing, err := FindNATRule(ctx, p, *op.NAT)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("NAT rule %s: %w", op.NAT.ID, ErrRuleNotFound)
+		}
+		return p.RemoveNAT(ctx, existing.ID)
+
+	case models.OpOpenPort:
+		switch op.Firewall.Type {
+		case models.RuleTypePortLimit:
+			return p.OpenPortForIP(ctx, *op.Firewall)
+		case models.RuleTypeTrustIP:
+			return p.TrustIP(ctx, *op.Firewall)
+		default:
+			return p.OpenPort(ctx, *op.Firewall)
+		}
+
+	case models.OpClosePort:
+		existing, err := FindFirewallRule(ctx, p, *op.Firewall)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("firewall rule %s: %w", op.Firewall.ID, ErrRuleNotFound)
+		}
+		return p.ClosePort(ctx, existing.ID)
+	}
+
+	return nil
+}
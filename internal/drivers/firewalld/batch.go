@@ -0,0 +1,114 @@
+package firewalld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ApplyBatch stages every operation in the permanent configuration and
+// activates them with a single reload. If a step or the reload fails, the
+// permanent changes made so far are reverted so the configuration matches
+// the runtime again; steps that could not be reverted are joined into the
+// returned error.
+func (d *Driver) ApplyBatch(ctx context.Context, ops []models.Operation) error {
+	for _, op := range ops {
+		if op.Kind == models.OpApplyNAT {
+			if err := d.enableIPForwarding(ctx); err != nil {
+				return fmt.Errorf("failed to enable IP forwarding: %w", err)
+			}
+			if err := d.enableMasquerade(ctx); err != nil {
+				return fmt.Errorf("failed to enable masquerade: %w", err)
+			}
+			break
+		}
+	}
+
+	var staged []models.Operation
+	for _, op := range ops {
+		if err := d.runPermanent(ctx, op); err != nil {
+			return d.revertStaged(ctx, staged, err)
+		}
+		staged = append(staged, op)
+	}
+
+	if err := d.reload(ctx); err != nil {
+		return d.revertStaged(ctx, staged, err)
+	}
+	return nil
+}
+
+// revertStaged undoes staged permanent changes in reverse order after
+// cause stopped the batch, returning cause joined with any revert failures
+func (d *Driver) revertStaged(ctx context.Context, staged []models.Operation, cause error) error {
+	var rollbackErrs []error
+	for i := len(staged) - 1; i >= 0; i-- {
+		if rbErr := d.runPermanent(ctx, staged[i].Inverse()); rbErr != nil {
+			rollbackErrs = append(rollbackErrs, rbErr)
+		}
+	}
+	if len(rollbackErrs) > 0 {
+		// The permanent configuration now differs from the runtime
+		return errors.Join(cause, fmt.Errorf("permanent configuration only partly reverted: %w", errors.Join(rollbackErrs...)))
+	}
+	return cause
+}
+
+// runPermanent applies one operation to the permanent configuration only
+func (d *Driver) runPermanent(ctx context.Context, op models.Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+
+	var args []string
+	switch op.Kind {
+	case models.OpApplyNAT:
+		if err := op.NAT.Validate(); err != nil {
+			return fmt.Errorf("invalid NAT rule: %w", err)
+		}
+		if err := d.checkNATMapped(ctx, *op.NAT); err != nil {
+			return err
+		}
+		args = []string{"--permanent", "--add-rich-rule", natRichRule(*op.NAT)}
+	case models.OpRemoveNAT:
+		args = []string{"--permanent", "--remove-rich-rule", natRichRule(*op.NAT)}
+	case models.OpOpenPort:
+		if err := op.Firewall.Validate(); err != nil {
+			return fmt.Errorf("invalid firewall rule: %w", err)
+		}
+		args = firewallRuleArgs(*op.Firewall, true)
+	case models.OpClosePort:
+		args = firewallRuleArgs(*op.Firewall, false)
+	}
+
+	cmd := exec.CommandContext(ctx, "firewall-cmd", args...)
+	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	if err != nil {
+		if op.Kind == models.OpOpenPort && strings.Contains(string(output), "already") {
+			return nil
+		}
+		return fmt.Errorf("failed to %s: %w (output: %s)", op.Kind, err, string(output))
+	}
+
+	return nil
+}
+
+// checkNATMapped returns an error if the rule's external port is already forwarded
+func (d *Driver) checkNATMapped(ctx context.Context, rule models.NATRule) error {
+	rules, err := d.ListNATRules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if r.ExternalPort == rule.ExternalPort && r.Proto == rule.Proto {
+			return fmt.Errorf("port %d/%s already mapped", rule.ExternalPort, rule.Proto)
+		}
+	}
+	return nil
+}
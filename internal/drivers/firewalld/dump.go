@@ -0,0 +1,24 @@
+package firewalld
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// Dump exports the permanent rich rules and ports of the default zone
+func (d *Driver) Dump(ctx context.Context) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for name, flag := range map[string]string{
+		"firewalld-rich-rules.txt": "--list-rich-rules",
+		"firewalld-ports.txt":      "--list-ports",
+	} {
+		cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", flag)
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("firewall-cmd %s failed: %w", flag, err)
+		}
+		files[name] = output
+	}
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package firewalld
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Export renders the rules as a firewalld zone file. Each rule is preceded
+// by the equivalent rich rule so the file can also be applied by hand with
+// firewall-cmd.
+func (d *Driver) Export(rules []models.AppliedRule) (string, []byte, error) {
+	var body strings.Builder
+	hasNAT := false
+	for i := range rules {
+		rule := rules[i]
+		if rule.IsNAT() {
+			hasNAT = true
+			nat := rule.NATRule
+			writeComment(&body, rule, natRichRule(nat))
+			fmt.Fprintf(&body, "  <forward-port port=\"%d\" protocol=\"%s\" to-port=\"%d\" to-addr=\"%s\"/>\n",
+				nat.ExternalPort, attr(strings.ToLower(string(nat.Proto))), nat.InternalPort, attr(nat.InternalIP))
+			continue
+		}
+
+		fw := rule.FirewallRule()
+		proto := attr(strings.ToLower(string(fw.Protocol)))
+		switch fw.Type {
+		case models.RuleTypePortLimit:
+			writeComment(&body, rule, filterRichRule(fw))
+			fmt.Fprintf(&body, "  <rule family=\"ipv4\">\n    <source address=\"%s\"/>\n    <port protocol=\"%s\" port=\"%d\"/>\n    <accept/>\n  </rule>\n",
+				attr(fw.SourceIP), proto, fw.Port)
+		case models.RuleTypeTrustIP:
+			writeComment(&body, rule, filterRichRule(fw))
+			fmt.Fprintf(&body, "  <rule family=\"ipv4\">\n    <source address=\"%s\"/>\n    <accept/>\n  </rule>\n", attr(fw.SourceIP))
+		default:
+			writeComment(&body, rule, fmt.Sprintf("port %d/%s", fw.Port, proto))
+			fmt.Fprintf(&body, "  <port protocol=\"%s\" port=\"%d\"/>\n", proto, fw.Port)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString("<zone>\n  <short>Portly</short>\n  <description>Rules exported by Portly</description>\n")
+	if hasNAT {
+		// Port forwards to other hosts need masquerading, as enableMasquerade sets up
+		sb.WriteString("  <masquerade/>\n")
+	}
+	sb.WriteString(body.String())
+	sb.WriteString("</zone>\n")
+	return "portly.xml", []byte(sb.String()), nil
+}
+
+// writeComment records the rule's ID and product and its firewall-cmd form
+func writeComment(sb *strings.Builder, rule models.AppliedRule, equivalent string) {
+	text := "ID: " + rule.ID
+	if rule.Product != "" {
+		text += ", Product: " + rule.Product
+	}
+	text += " | " + equivalent
+	fmt.Fprintf(sb, "  <!-- %s -->\n", strings.ReplaceAll(text, "--", "- -"))
+}
+
+// attr escapes a value for use inside an XML attribute
+func attr(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
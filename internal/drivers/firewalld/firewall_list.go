@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -28,27 +29,11 @@ func (d *Driver) ClosePort(ctx context.Context, ruleID string) error {
 
 // removeFirewallRule removes a specific firewall rule
 func (d *Driver) removeFirewallRule(ctx context.Context, rule models.FirewallRule) error {
-	proto := strings.ToLower(string(rule.Protocol))
-
-	switch rule.Type {
-	case models.RuleTypePort:
-		portStr := fmt.Sprintf("%d/%s", rule.Port, proto)
-		cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--remove-port", portStr)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return fmt.Errorf("failed to close port: %w (output: %s)", err, string(output))
-		}
-
-	case models.RuleTypePortLimit:
-		richRule := fmt.Sprintf(
-			`rule family="ipv4" source address="%s" port protocol="%s" port="%d" accept`,
-			rule.SourceIP, proto, rule.Port,
-		)
-		cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--remove-rich-rule", richRule)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return fmt.Errorf("failed to remove IP-limited rule: %w (output: %s)", err, string(output))
-		}
+	cmd := exec.CommandContext(ctx, "firewall-cmd", firewallRuleArgs(rule, false)...)
+	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	if err != nil {
+		return fmt.Errorf("failed to remove firewall rule: %w (output: %s)", err, string(output))
 	}
 
 	return d.reload(ctx)
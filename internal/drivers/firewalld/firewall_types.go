@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -20,6 +21,7 @@ func (d *Driver) OpenPort(ctx context.Context, rule models.FirewallRule) error {
 
 	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-port", portStr)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		if strings.Contains(string(output), "already") {
 			return nil
@@ -36,15 +38,9 @@ func (d *Driver) OpenPortForIP(ctx context.Context, rule models.FirewallRule) er
 		return fmt.Errorf("invalid firewall rule: %w", err)
 	}
 
-	richRule := fmt.Sprintf(
-		`rule family="ipv4" source address="%s" port protocol="%s" port="%d" accept`,
-		rule.SourceIP,
-		strings.ToLower(string(rule.Protocol)),
-		rule.Port,
-	)
-
-	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", richRule)
+	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", filterRichRule(rule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		if strings.Contains(string(output), "already") {
 			return nil
@@ -61,13 +57,9 @@ func (d *Driver) TrustIP(ctx context.Context, rule models.FirewallRule) error {
 		return fmt.Errorf("invalid firewall rule: %w", err)
 	}
 
-	richRule := fmt.Sprintf(
-		`rule family="ipv4" source address="%s" accept`,
-		rule.SourceIP,
-	)
-
-	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", richRule)
+	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", filterRichRule(rule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		if strings.Contains(string(output), "already") {
 			return nil
@@ -6,7 +6,6 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
@@ -39,9 +38,7 @@ func (d *Driver) ListNATRules(ctx context.Context) ([]models.NATRule, error) {
 
 // parseRichRule parses a firewalld rich rule string into a NATRule
 func (d *Driver) parseRichRule(ruleStr string) (*models.NATRule, error) {
-	rule := &models.NATRule{
-		ID: fmt.Sprintf("fw-%d", time.Now().UnixNano()),
-	}
+	rule := &models.NATRule{}
 
 	if port := extractValue(ruleStr, `port="`); port != "" {
 		if p, err := strconv.Atoi(port); err == nil {
@@ -50,7 +47,7 @@ func (d *Driver) parseRichRule(ruleStr string) (*models.NATRule, error) {
 	}
 
 	if proto := extractValue(ruleStr, `protocol="`); proto != "" {
-		rule.Proto = models.Protocol(strings.ToUpper(proto))
+		rule.Proto = models.Protocol(strings.ToLower(proto))
 	}
 
 	if toPort := extractValue(ruleStr, `to-port="`); toPort != "" {
@@ -67,6 +64,9 @@ func (d *Driver) parseRichRule(ruleStr string) (*models.NATRule, error) {
 		return nil, fmt.Errorf("could not parse rule")
 	}
 
+	// Derive the ID from the rule itself so it stays stable across listings
+	rule.ID = fmt.Sprintf("fw-nat-%d-%s-%s-%d", rule.ExternalPort, rule.Proto, rule.InternalIP, rule.InternalPort)
+
 	return rule, nil
 }
 
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
-	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -35,16 +35,9 @@ func (d *Driver) ApplyNAT(ctx context.Context, rule models.NATRule) error {
 		return fmt.Errorf("failed to enable masquerade: %w", err)
 	}
 
-	richRule := fmt.Sprintf(
-		`rule family="ipv4" forward-port port="%d" protocol="%s" to-port="%d" to-addr="%s"`,
-		rule.ExternalPort,
-		strings.ToLower(string(rule.Proto)),
-		rule.InternalPort,
-		rule.InternalIP,
-	)
-
-	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", richRule)
+	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--add-rich-rule", natRichRule(rule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to add NAT rule: %w (output: %s)", err, string(output))
 	}
@@ -75,16 +68,9 @@ func (d *Driver) RemoveNAT(ctx context.Context, ruleID string) error {
 		return errors.New("rule not found")
 	}
 
-	richRule := fmt.Sprintf(
-		`rule family="ipv4" forward-port port="%d" protocol="%s" to-port="%d" to-addr="%s"`,
-		targetRule.ExternalPort,
-		strings.ToLower(string(targetRule.Proto)),
-		targetRule.InternalPort,
-		targetRule.InternalIP,
-	)
-
-	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--remove-rich-rule", richRule)
+	cmd := exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--remove-rich-rule", natRichRule(*targetRule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to remove NAT rule: %w (output: %s)", err, string(output))
 	}
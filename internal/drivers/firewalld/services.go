@@ -0,0 +1,87 @@
+package firewalld
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ImplicitAllows returns the ports of the services the default zone allows,
+// and whether the zone's target accepts everything
+func (d *Driver) ImplicitAllows(ctx context.Context) ([]models.FirewallRule, bool, error) {
+	cmd := exec.CommandContext(ctx, "firewall-cmd", "--list-all")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list zone: %w", err)
+	}
+	zone := parseZoneInfo(string(output))
+	if zone["target"] == "ACCEPT" {
+		return nil, true, nil
+	}
+
+	var rules []models.FirewallRule
+	for _, service := range strings.Fields(zone["services"]) {
+		cmd := exec.CommandContext(ctx, "firewall-cmd", "--info-service="+service)
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read service %s: %w", service, err)
+		}
+		for _, port := range strings.Fields(parseZoneInfo(string(output))["ports"]) {
+			rules = append(rules, serviceRules(service, port)...)
+		}
+	}
+	return rules, false, nil
+}
+
+// parseZoneInfo reads the "key: value" lines of --list-all and
+// --info-service output
+func parseZoneInfo(output string) map[string]string {
+	info := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok {
+			info[key] = strings.TrimSpace(value)
+		}
+	}
+	return info
+}
+
+// serviceRules expands a service port such as "22/tcp" or "60000-61000/udp"
+// into rules, skipping ranges too wide to list
+func serviceRules(service, port string) []models.FirewallRule {
+	ports, protoStr, ok := strings.Cut(port, "/")
+	if !ok {
+		return nil
+	}
+	proto := models.TCP
+	if protoStr == "udp" {
+		proto = models.UDP
+	}
+	startStr, endStr, isRange := strings.Cut(ports, "-")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return nil
+	}
+	end := start
+	if isRange {
+		if end, err = strconv.Atoi(endStr); err != nil || end < start || end-start > 1024 {
+			return nil
+		}
+	}
+
+	var rules []models.FirewallRule
+	for p := start; p <= end; p++ {
+		rules = append(rules, models.FirewallRule{
+			ID:          fmt.Sprintf("fw-service-%s-%d-%s", service, p, proto),
+			Type:        models.RuleTypePort,
+			Port:        p,
+			Protocol:    proto,
+			Description: "firewalld service " + service,
+		})
+	}
+	return rules
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
 func (d *Driver) enableIPForwarding(ctx context.Context) error {
@@ -15,7 +18,9 @@ func (d *Driver) enableIPForwarding(ctx context.Context) error {
 	}
 
 	cmd = exec.CommandContext(ctx, "sysctl", "-w", "net.ipv4.ip_forward=1")
-	if output, err := cmd.CombinedOutput(); err != nil {
+	output, err = cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	if err != nil {
 		return fmt.Errorf("failed to enable IP forwarding: %w (output: %s)", err, string(output))
 	}
 
@@ -37,7 +42,9 @@ func (d *Driver) enableMasquerade(ctx context.Context) error {
 	}
 
 	cmd = exec.CommandContext(ctx, "firewall-cmd", "--permanent", "--zone", zone, "--add-masquerade")
-	if output, err := cmd.CombinedOutput(); err != nil {
+	output, err = cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	if err != nil {
 		return fmt.Errorf("failed to enable masquerade: %w (output: %s)", err, string(output))
 	}
 
@@ -47,8 +54,52 @@ func (d *Driver) enableMasquerade(ctx context.Context) error {
 func (d *Driver) reload(ctx context.Context) error {
 	cmd := exec.CommandContext(ctx, "firewall-cmd", "--reload")
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("firewall-cmd --reload failed: %w (output: %s)", err, string(output))
 	}
 	return nil
 }
+
+// natRichRule renders the rich rule used for a port forward
+func natRichRule(rule models.NATRule) string {
+	return fmt.Sprintf(
+		`rule family="ipv4" forward-port port="%d" protocol="%s" to-port="%d" to-addr="%s"`,
+		rule.ExternalPort,
+		strings.ToLower(string(rule.Proto)),
+		rule.InternalPort,
+		rule.InternalIP,
+	)
+}
+
+// filterRichRule renders the rich rule for an IP-limited port or trusted IP
+func filterRichRule(rule models.FirewallRule) string {
+	if rule.Type == models.RuleTypeTrustIP {
+		return fmt.Sprintf(`rule family="ipv4" source address="%s" accept`, rule.SourceIP)
+	}
+	return fmt.Sprintf(
+		`rule family="ipv4" source address="%s" port protocol="%s" port="%d" accept`,
+		rule.SourceIP,
+		strings.ToLower(string(rule.Protocol)),
+		rule.Port,
+	)
+}
+
+// firewallRuleArgs returns the permanent firewall-cmd arguments that add or
+// remove a firewall rule
+func firewallRuleArgs(rule models.FirewallRule, add bool) []string {
+	if rule.Type == models.RuleTypePortLimit || rule.Type == models.RuleTypeTrustIP {
+		action := "--remove-rich-rule"
+		if add {
+			action = "--add-rich-rule"
+		}
+		return []string{"--permanent", action, filterRichRule(rule)}
+	}
+
+	action := "--remove-port"
+	if add {
+		action = "--add-port"
+	}
+	portStr := fmt.Sprintf("%d/%s", rule.Port, strings.ToLower(string(rule.Protocol)))
+	return []string{"--permanent", action, portStr}
+}
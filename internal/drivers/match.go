@@ -0,0 +1,36 @@
+package drivers
+
+import (
+	"context"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// FindNATRule returns the provider's copy of a NAT rule, carrying the
+// backend-specific ID needed to remove it
+func FindNATRule(ctx context.Context, p Provider, rule models.NATRule) (*models.NATRule, error) {
+	rules, err := p.ListNATRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// FindFirewallRule returns the provider's copy of a firewall rule
+func FindFirewallRule(ctx context.Context, p Provider, rule models.FirewallRule) (*models.FirewallRule, error) {
+	rules, err := p.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].Matches(rule) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
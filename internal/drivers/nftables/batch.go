@@ -0,0 +1,100 @@
+package nftables
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ApplyBatch applies all operations in a single `nft -f` transaction, so
+// either every change lands or none does
+func (d *Driver) ApplyBatch(ctx context.Context, ops []models.Operation) error {
+	if err := d.ensureTableAndChain(ctx); err != nil {
+		return fmt.Errorf("failed to ensure table structure: %w", err)
+	}
+	if err := d.ensureFilterTable(ctx); err != nil {
+		return err
+	}
+
+	// Ports forwarded by rules removed earlier in the batch may be reused
+	freed := make(map[string]bool)
+
+	var script strings.Builder
+	for _, op := range ops {
+		line, err := d.batchLine(ctx, op, freed)
+		if err != nil {
+			return err
+		}
+		script.WriteString(line + "\n")
+	}
+
+	cmd := exec.CommandContext(ctx, "nft", "-f", "-")
+	cmd.Stdin = strings.NewReader(script.String())
+	output, err := cmd.CombinedOutput()
+	platform.RecordScript(ctx, cmd, script.String(), output, err)
+	if err != nil {
+		return fmt.Errorf("nft batch failed: %w (output: %s)", err, string(output))
+	}
+
+	return d.saveRules(ctx)
+}
+
+// batchLine renders one operation as an nft script command
+func (d *Driver) batchLine(ctx context.Context, op models.Operation, freed map[string]bool) (string, error) {
+	if err := op.Validate(); err != nil {
+		return "", err
+	}
+
+	switch op.Kind {
+	case models.OpApplyNAT:
+		if err := op.NAT.Validate(); err != nil {
+			return "", fmt.Errorf("invalid NAT rule: %w", err)
+		}
+		if !freed[natPortKey(*op.NAT)] {
+			if err := d.CheckConflicts(ctx, op.NAT.ExternalPort, op.NAT.Proto); err != nil {
+				return "", err
+			}
+		}
+		return fmt.Sprintf("add rule inet %s %s %s", tableName, chainName, d.buildNATRule(*op.NAT)), nil
+
+	case models.OpRemoveNAT:
+		handle, err := d.findRuleHandle(ctx, *op.NAT)
+		if err != nil {
+			return "", fmt.Errorf("failed to find rule handle: %w", err)
+		}
+		if handle == "" {
+			return "", fmt.Errorf("could not find NAT rule %s to remove", op.NAT.ID)
+		}
+		freed[natPortKey(*op.NAT)] = true
+		return fmt.Sprintf("delete rule inet %s %s handle %s", tableName, chainName, handle), nil
+
+	case models.OpOpenPort:
+		if op.Firewall.Type == models.RuleTypeTrustIP {
+			return "", fmt.Errorf("TrustIP not implemented for nftables")
+		}
+		if err := op.Firewall.Validate(); err != nil {
+			return "", fmt.Errorf("invalid firewall rule: %w", err)
+		}
+		return fmt.Sprintf("add rule inet %s %s %s", filterTableName, filterChainName, d.buildFilterRule(*op.Firewall)), nil
+
+	case models.OpClosePort:
+		handle, err := d.findFilterRuleHandle(ctx, *op.Firewall)
+		if err != nil {
+			return "", err
+		}
+		if handle == "" {
+			return "", fmt.Errorf("firewall rule %s not found in firewall", op.Firewall.ID)
+		}
+		return fmt.Sprintf("delete rule inet %s %s handle %s", filterTableName, filterChainName, handle), nil
+	}
+
+	return "", fmt.Errorf("unsupported operation %s", op.Kind)
+}
+
+func natPortKey(rule models.NATRule) string {
+	return fmt.Sprintf("%d/%s", rule.ExternalPort, strings.ToLower(string(rule.Proto)))
+}
@@ -0,0 +1,21 @@
+package nftables
+
+import (
+	"context"
+	"os/exec"
+)
+
+// Dump exports the Portly NAT and filter tables as nft scripts
+func (d *Driver) Dump(ctx context.Context) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, table := range []string{tableName, filterTableName} {
+		cmd := exec.CommandContext(ctx, "nft", "list", "table", "inet", table)
+		output, err := cmd.Output()
+		if err != nil {
+			// Table not created yet
+			continue
+		}
+		files[table+".nft"] = output
+	}
+	return files, nil
+}
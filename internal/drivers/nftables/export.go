@@ -0,0 +1,49 @@
+package nftables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Export renders the rules as an `nft -f` script that recreates Portly's
+// tables on any nftables host
+func (d *Driver) Export(rules []models.AppliedRule) (string, []byte, error) {
+	var nat, filter []string
+	for i := range rules {
+		rule := rules[i]
+		comment := "\t\t# ID: " + rule.ID
+		if rule.Product != "" {
+			comment += ", Product: " + rule.Product
+		}
+		if rule.IsNAT() {
+			nat = append(nat, comment, "\t\t"+d.buildNATRule(rule.NATRule))
+			continue
+		}
+		fw := rule.FirewallRule()
+		expr := d.buildFilterRule(fw)
+		if fw.Type == models.RuleTypeTrustIP {
+			expr = fmt.Sprintf("ip saddr %s accept", fw.SourceIP)
+		}
+		filter = append(filter, comment, "\t\t"+expr)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/usr/sbin/nft -f\n# Exported by Portly\n\n")
+	writeTable(&sb, tableName, chainName, "type nat hook prerouting priority dstnat; policy accept;", nat)
+	sb.WriteString("\n")
+	writeTable(&sb, filterTableName, filterChainName, "type filter hook input priority 0; policy accept;", filter)
+	return "portly.nft", []byte(sb.String()), nil
+}
+
+// writeTable renders a table with a single base chain. The table is added
+// and flushed first so the script can be loaded repeatedly.
+func writeTable(sb *strings.Builder, table, chain, hook string, lines []string) {
+	fmt.Fprintf(sb, "add table inet %s\nflush table inet %s\n", table, table)
+	fmt.Fprintf(sb, "table inet %s {\n\tchain %s {\n\t\t%s\n", table, chain, hook)
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\t}\n}\n")
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -39,6 +40,7 @@ func (d *Driver) removeFirewallRule(ctx context.Context, rule models.FirewallRul
 
 	cmd := exec.CommandContext(ctx, "nft", "delete", "rule", "inet", filterTableName, filterChainName, "handle", handle)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to remove rule: %w (output: %s)", err, string(output))
 	}
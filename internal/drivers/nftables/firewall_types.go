@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -24,11 +25,9 @@ func (d *Driver) OpenPort(ctx context.Context, rule models.FirewallRule) error {
 		return err
 	}
 
-	proto := strings.ToLower(string(rule.Protocol))
-	ruleStr := fmt.Sprintf("%s dport %d accept", proto, rule.Port)
-
-	cmd := exec.CommandContext(ctx, "nft", "add", "rule", "inet", filterTableName, filterChainName, ruleStr)
+	cmd := exec.CommandContext(ctx, "nft", "add", "rule", "inet", filterTableName, filterChainName, d.buildFilterRule(rule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to open port: %w (output: %s)", err, string(output))
 	}
@@ -46,11 +45,9 @@ func (d *Driver) OpenPortForIP(ctx context.Context, rule models.FirewallRule) er
 		return err
 	}
 
-	proto := strings.ToLower(string(rule.Protocol))
-	ruleStr := fmt.Sprintf("ip saddr %s %s dport %d accept", rule.SourceIP, proto, rule.Port)
-
-	cmd := exec.CommandContext(ctx, "nft", "add", "rule", "inet", filterTableName, filterChainName, ruleStr)
+	cmd := exec.CommandContext(ctx, "nft", "add", "rule", "inet", filterTableName, filterChainName, d.buildFilterRule(rule))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to add IP-limited rule: %w (output: %s)", err, string(output))
 	}
@@ -58,6 +55,15 @@ func (d *Driver) OpenPortForIP(ctx context.Context, rule models.FirewallRule) er
 	return d.saveRules(ctx)
 }
 
+// buildFilterRule renders the nft expression for a port or IP-limited rule
+func (d *Driver) buildFilterRule(rule models.FirewallRule) string {
+	proto := strings.ToLower(string(rule.Protocol))
+	if rule.Type == models.RuleTypePortLimit {
+		return fmt.Sprintf("ip saddr %s %s dport %d accept", rule.SourceIP, proto, rule.Port)
+	}
+	return fmt.Sprintf("%s dport %d accept", proto, rule.Port)
+}
+
 // ensureFilterTable ensures the filter table and chain exist
 func (d *Driver) ensureFilterTable(ctx context.Context) error {
 	cmd := exec.CommandContext(ctx, "nft", "list", "table", "inet", filterTableName)
@@ -66,6 +72,7 @@ func (d *Driver) ensureFilterTable(ctx context.Context) error {
 	if err != nil {
 		cmd = exec.CommandContext(ctx, "nft", "add", "table", "inet", filterTableName)
 		output, err := cmd.CombinedOutput()
+		platform.RecordCommand(ctx, cmd, output, err)
 		if err != nil {
 			return fmt.Errorf("failed to create filter table: %w (output: %s)", err, string(output))
 		}
@@ -78,6 +85,7 @@ func (d *Driver) ensureFilterTable(ctx context.Context) error {
 		cmd = exec.CommandContext(ctx, "nft", "add", "chain", "inet", filterTableName, filterChainName,
 			"{ type filter hook input priority 0; policy accept; }")
 		output, err := cmd.CombinedOutput()
+		platform.RecordCommand(ctx, cmd, output, err)
 		if err != nil {
 			return fmt.Errorf("failed to create filter chain: %w (output: %s)", err, string(output))
 		}
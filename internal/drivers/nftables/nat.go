@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -35,6 +36,7 @@ func (d *Driver) ApplyNAT(ctx context.Context, rule models.NATRule) error {
 
 	cmd := exec.CommandContext(ctx, "nft", "add", "rule", "inet", tableName, chainName, ruleStr)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to add NAT rule: %w (output: %s)", err, string(output))
 	}
@@ -76,6 +78,7 @@ func (d *Driver) RemoveNAT(ctx context.Context, ruleID string) error {
 
 	cmd := exec.CommandContext(ctx, "nft", "delete", "rule", "inet", tableName, chainName, "handle", handle)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to remove NAT rule: %w (output: %s)", err, string(output))
 	}
@@ -0,0 +1,27 @@
+package nftables
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ImplicitAllows reports whether every input chain, Portly's and any other
+// table's, has an accept policy, in which case ports without a rule are
+// not firewalled
+func (d *Driver) ImplicitAllows(ctx context.Context) ([]models.FirewallRule, bool, error) {
+	cmd := exec.CommandContext(ctx, "nft", "list", "chains")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list chains: %w", err)
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, "hook input") && strings.Contains(line, "policy drop") {
+			return nil, false, nil
+		}
+	}
+	return nil, true, nil
+}
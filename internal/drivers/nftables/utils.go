@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -23,6 +24,7 @@ func (d *Driver) ensureTableAndChain(ctx context.Context) error {
 	if err != nil {
 		cmd = exec.CommandContext(ctx, "nft", "add", "table", "inet", tableName)
 		output, err := cmd.CombinedOutput()
+		platform.RecordCommand(ctx, cmd, output, err)
 		if err != nil {
 			return fmt.Errorf("failed to create table: %w (output: %s)", err, string(output))
 		}
@@ -35,6 +37,7 @@ func (d *Driver) ensureTableAndChain(ctx context.Context) error {
 		cmd = exec.CommandContext(ctx, "nft", "add", "chain", "inet", tableName, chainName,
 			"{ type nat hook prerouting priority dstnat; policy accept; }")
 		output, err := cmd.CombinedOutput()
+		platform.RecordCommand(ctx, cmd, output, err)
 		if err != nil {
 			return fmt.Errorf("failed to create chain: %w (output: %s)", err, string(output))
 		}
@@ -0,0 +1,24 @@
+package pf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dump exports the Portly anchor files
+func (d *Driver) Dump(ctx context.Context) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, path := range []string{anchorFile, portlyAnchorFile} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read anchor %s: %w", path, err)
+		}
+		files[filepath.Base(path)+".conf"] = content
+	}
+	return files, nil
+}
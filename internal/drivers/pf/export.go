@@ -0,0 +1,29 @@
+package pf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Export renders the rules as a pf anchor file. Redirections come before
+// filter rules, as pf requires translation rules first.
+func (d *Driver) Export(rules []models.AppliedRule) (string, []byte, error) {
+	var nat, filter []string
+	for i := range rules {
+		rule := rules[i]
+		if rule.IsNAT() {
+			nat = append(nat, d.buildPFRule(rule.NATRule))
+			continue
+		}
+		filter = append(filter, d.buildFilterRule(rule.FirewallRule()))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Exported by Portly\n# Load with: pfctl -a %s -f <file>\n\n", anchorName))
+	for _, block := range append(nat, filter...) {
+		sb.WriteString(strings.TrimSuffix(block, "\n") + "\n\n")
+	}
+	return anchorName + ".conf", []byte(sb.String()), nil
+}
@@ -26,11 +26,7 @@ func (d *Driver) OpenPort(ctx context.Context, rule models.FirewallRule) error {
 		return fmt.Errorf("invalid firewall rule: %w", err)
 	}
 
-	proto := strings.ToLower(string(rule.Protocol))
-	ruleStr := fmt.Sprintf("# ID: %s\n# Type: port\npass in inet proto %s to any port %d\n",
-		rule.ID, proto, rule.Port)
-
-	return d.appendToAnchor(ctx, ruleStr)
+	return d.appendToAnchor(ctx, d.buildFilterRule(rule))
 }
 
 // OpenPortForIP opens a port limited to a specific source IP
@@ -39,11 +35,21 @@ func (d *Driver) OpenPortForIP(ctx context.Context, rule models.FirewallRule) er
 		return fmt.Errorf("invalid firewall rule: %w", err)
 	}
 
-	proto := strings.ToLower(string(rule.Protocol))
-	ruleStr := fmt.Sprintf("# ID: %s\n# Type: port_limit\npass in inet proto %s from %s to any port %d\n",
-		rule.ID, proto, rule.SourceIP, rule.Port)
+	return d.appendToAnchor(ctx, d.buildFilterRule(rule))
+}
 
-	return d.appendToAnchor(ctx, ruleStr)
+// buildFilterRule renders a pass rule with its ID and type comments
+func (d *Driver) buildFilterRule(rule models.FirewallRule) string {
+	proto := strings.ToLower(string(rule.Protocol))
+	switch rule.Type {
+	case models.RuleTypePortLimit:
+		return fmt.Sprintf("# ID: %s\n# Type: port_limit\npass in inet proto %s from %s to any port %d\n",
+			rule.ID, proto, rule.SourceIP, rule.Port)
+	case models.RuleTypeTrustIP:
+		return fmt.Sprintf("# ID: %s\n# Type: trust_ip\npass in inet from %s to any\n", rule.ID, rule.SourceIP)
+	}
+	return fmt.Sprintf("# ID: %s\n# Type: port\npass in inet proto %s to any port %d\n",
+		rule.ID, proto, rule.Port)
 }
 
 // appendToAnchor appends a rule to the PF anchor file
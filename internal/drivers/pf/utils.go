@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -19,6 +20,7 @@ func (d *Driver) enablePF(ctx context.Context) error {
 
 	cmd = exec.CommandContext(ctx, pfctlPath, "-e")
 	output, err = cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to enable PF: %w (output: %s)", err, string(output))
 	}
@@ -90,6 +92,7 @@ func (d *Driver) buildPFRule(rule models.NATRule) string {
 func (d *Driver) loadAnchor(ctx context.Context) error {
 	cmd := exec.CommandContext(ctx, pfctlPath, "-a", anchorName, "-f", anchorFile)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("pfctl failed: %w (output: %s)", err, string(output))
 	}
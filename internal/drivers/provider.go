@@ -2,6 +2,7 @@ package drivers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/orchestrator/unified-firewall/internal/drivers/firewalld"
 	"github.com/orchestrator/unified-firewall/internal/drivers/nftables"
@@ -36,6 +37,46 @@ type Provider interface {
 	RemoveSecurityPolicy(ctx context.Context, product string) error
 }
 
+// BatchApplier is implemented by providers that can apply several
+// operations as a single backend transaction. ApplyBatch must leave the
+// firewall untouched when it returns an error.
+type BatchApplier interface {
+	ApplyBatch(ctx context.Context, ops []models.Operation) error
+}
+
+// Dumper is implemented by providers that can export their native ruleset
+// for backups. Dump returns file names mapped to contents.
+type Dumper interface {
+	Dump(ctx context.Context) (map[string][]byte, error)
+}
+
+// ImplicitAllower is implemented by providers whose backend accepts traffic
+// beyond the port rules they list, such as firewalld services. all is true
+// when the backend accepts every port.
+type ImplicitAllower interface {
+	ImplicitAllows(ctx context.Context) (rules []models.FirewallRule, all bool, err error)
+}
+
+// Exporter is implemented by providers that can render managed rules as
+// a native configuration file, independent of the host they run on
+type Exporter interface {
+	Export(rules []models.AppliedRule) (name string, data []byte, err error)
+}
+
+// NewExporter returns the exporter for a backend name, without requiring
+// that backend to be available on this host
+func NewExporter(backend string) (Exporter, error) {
+	switch backend {
+	case "firewalld":
+		return firewalld.New(), nil
+	case "nftables":
+		return nftables.New(), nil
+	case "pf":
+		return pf.New(), nil
+	}
+	return nil, fmt.Errorf("unknown export format '%s' (use nftables, firewalld or pf)", backend)
+}
+
 // ProviderFactory creates providers based on OS detection
 type ProviderFactory struct {
 	providers []Provider
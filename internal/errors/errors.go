@@ -12,4 +12,10 @@ var (
 	ErrPermissionDenied     = errors.New("root/admin privileges required")
 	ErrSecurityPolicyFailed = errors.New("failed to apply security policy")
 	ErrUnsupportedProduct   = errors.New("unsupported product")
+	ErrSSHLockout           = errors.New("change would cut off the current SSH session")
+	ErrPendingChange        = errors.New("an unconfirmed change is already pending")
+	ErrStateLocked          = errors.New("state file is locked by another process")
+	ErrStateChanged         = errors.New("state file was changed by another process")
+	ErrStateTooNew          = errors.New("state file was written by a newer version of Portly")
+	ErrStateTampered        = errors.New("state was modified outside Portly")
 )
@@ -0,0 +1,83 @@
+package expose
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Operations builds one operation for every port the catalog lists for a
+// product, each with its own protocol
+func Operations(product catalog.Product, opts Options) ([]models.Operation, error) {
+	if len(product.Ports) == 0 {
+		return nil, fmt.Errorf("product %s has no ports in the catalog", product.Name)
+	}
+
+	var ops []models.Operation
+	for _, port := range product.Ports {
+		description := fmt.Sprintf("%s %d/%s", product.Title(), port.Port, port.Protocol)
+		if opts.InternalIP != "" {
+			rule := models.NATRule{
+				ID:           uuid.New().String()[:8],
+				Product:      product.Name,
+				ExternalPort: port.Port,
+				InternalIP:   opts.InternalIP,
+				InternalPort: port.Port,
+				Proto:        port.Protocol,
+				Description:  description,
+			}
+			if err := rule.Validate(); err != nil {
+				return nil, err
+			}
+			ops = append(ops, models.Operation{Kind: models.OpApplyNAT, NAT: &rule})
+			continue
+		}
+
+		rule := models.FirewallRule{
+			ID:          uuid.New().String()[:8],
+			Type:        models.RuleTypePort,
+			Port:        port.Port,
+			Protocol:    port.Protocol,
+			Description: description,
+			Product:     product.Name,
+		}
+		if opts.SourceIP != "" {
+			rule.Type = models.RuleTypePortLimit
+			rule.SourceIP = opts.SourceIP
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		ops = append(ops, models.Operation{Kind: models.OpOpenPort, Firewall: &rule})
+	}
+	return ops, nil
+}
+
+// Expose stages every port of a catalog product as one transaction under
+// a new group. The caller commits it, so it can use commit-confirmed.
+func Expose(provider drivers.Provider, stateMgr *state.Manager, name string, opts Options) (*transaction.Transaction, string, error) {
+	product, ok := catalog.Default().Get(name)
+	if !ok {
+		return nil, "", fmt.Errorf("product '%s' is not in the catalog", name)
+	}
+	ops, err := Operations(product, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	group := fmt.Sprintf("%s-%s", product.Name, uuid.New().String()[:8])
+	txn := transaction.New(provider, stateMgr)
+	txn.SetGroup(group)
+	for _, op := range ops {
+		if err := txn.Stage(op); err != nil {
+			return nil, "", err
+		}
+	}
+	return txn, group, nil
+}
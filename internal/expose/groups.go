@@ -0,0 +1,126 @@
+package expose
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// List returns the groups in state, ignoring rules already removed
+func List(stateMgr *state.Manager) []Group {
+	byName := make(map[string]*Group)
+	for _, rule := range stateMgr.ListRules() {
+		if rule.Group == "" || rule.Status == models.StatusRemoved {
+			continue
+		}
+		g, ok := byName[rule.Group]
+		if !ok {
+			g = &Group{Name: rule.Group, Product: rule.Product}
+			byName[rule.Group] = g
+		}
+		g.Rules = append(g.Rules, rule)
+	}
+
+	groups := make([]Group, 0, len(byName))
+	for _, g := range byName {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// Get returns one group by name
+func Get(stateMgr *state.Manager, name string) (*Group, error) {
+	for _, g := range List(stateMgr) {
+		if g.Name == name {
+			return &g, nil
+		}
+	}
+	return nil, fmt.Errorf("group '%s' not found", name)
+}
+
+// Disable stages removal of the group's active rules from the firewall,
+// keeping them in state as disabled
+func Disable(provider drivers.Provider, stateMgr *state.Manager, name string) (*transaction.Transaction, error) {
+	g, err := Get(stateMgr, name)
+	if err != nil {
+		return nil, err
+	}
+	txn := transaction.New(provider, stateMgr)
+	txn.SetDisable(true)
+	for i := range g.Rules {
+		if g.Rules[i].Status == models.StatusActive {
+			if err := txn.Stage(removal(g.Rules[i])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return txn, nil
+}
+
+// Enable stages re-applying the group's disabled rules
+func Enable(provider drivers.Provider, stateMgr *state.Manager, name string) (*transaction.Transaction, error) {
+	g, err := Get(stateMgr, name)
+	if err != nil {
+		return nil, err
+	}
+	txn := transaction.New(provider, stateMgr)
+	txn.SetGroup(g.Name)
+	for i := range g.Rules {
+		if g.Rules[i].Status != models.StatusActive {
+			if err := txn.Stage(g.Rules[i].Operation()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return txn, nil
+}
+
+// Remove stages removal of the group's active rules. Disabled rules are
+// not on the firewall, so the caller marks them removed with Forget once
+// the transaction commits.
+func Remove(provider drivers.Provider, stateMgr *state.Manager, name string) (*transaction.Transaction, error) {
+	g, err := Get(stateMgr, name)
+	if err != nil {
+		return nil, err
+	}
+	txn := transaction.New(provider, stateMgr)
+	for i := range g.Rules {
+		if g.Rules[i].Status == models.StatusActive {
+			if err := txn.Stage(removal(g.Rules[i])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return txn, nil
+}
+
+// Forget marks the group's remaining rules removed in state, for rules
+// that are not on the firewall
+func Forget(stateMgr *state.Manager, name string) error {
+	g, err := Get(stateMgr, name)
+	if err != nil {
+		return err
+	}
+	for _, rule := range g.Rules {
+		rule.Status = models.StatusRemoved
+		if err := stateMgr.UpdateRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removal returns the operation that takes a recorded rule off the firewall
+func removal(rule models.AppliedRule) models.Operation {
+	if rule.IsNAT() {
+		nat := rule.NATRule
+		return models.Operation{Kind: models.OpRemoveNAT, NAT: &nat}
+	}
+	fw := rule.FirewallRule()
+	return models.Operation{Kind: models.OpClosePort, Firewall: &fw}
+}
@@ -0,0 +1,50 @@
+// Package expose creates all of a product's rules as one group and manages
+// such groups together
+package expose
+
+import (
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Options selects how a product is exposed
+type Options struct {
+	// InternalIP forwards each port to this host with NAT rules; when
+	// empty the ports are opened on this host
+	InternalIP string
+	// SourceIP limits opened ports to a single client
+	SourceIP string
+}
+
+// Group is a set of rules created together
+type Group struct {
+	Name    string
+	Product string
+	Rules   []models.AppliedRule
+}
+
+// Group states
+const (
+	StateActive   = "active"
+	StateDisabled = "disabled"
+	StatePartial  = "partial"
+)
+
+// State summarizes the status of the group's rules
+func (g *Group) State() string {
+	active, disabled := 0, 0
+	for _, r := range g.Rules {
+		switch r.Status {
+		case models.StatusActive:
+			active++
+		case models.StatusDisabled:
+			disabled++
+		}
+	}
+	switch {
+	case active == len(g.Rules):
+		return StateActive
+	case disabled == len(g.Rules):
+		return StateDisabled
+	}
+	return StatePartial
+}
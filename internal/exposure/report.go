@@ -0,0 +1,152 @@
+package exposure
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/sockets"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Build lists the listening sockets and the provider's rules and joins them
+func Build(ctx context.Context, provider drivers.Provider) (*Report, error) {
+	if provider == nil {
+		return nil, drivers.ErrNoProviderAvailable
+	}
+	listeners, err := sockets.List()
+	if err != nil {
+		return nil, err
+	}
+	fwRules, err := provider.ListFirewallRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	natRules, err := provider.ListNATRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var allowed []models.FirewallRule
+	acceptAll := false
+	if allower, ok := provider.(drivers.ImplicitAllower); ok {
+		if allowed, acceptAll, err = allower.ImplicitAllows(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return Analyze(listeners, fwRules, natRules, localAddresses(), allowed, acceptAll), nil
+}
+
+// Analyze joins listeners with rules. local holds this host's addresses,
+// used to tell which NAT targets can be checked. allowed are ports the
+// backend opens outside its rules, such as firewalld services, and
+// acceptAll means no port is firewalled.
+func Analyze(listeners []sockets.Listener, fwRules []models.FirewallRule, natRules []models.NATRule, local []net.IP, allowed []models.FirewallRule, acceptAll bool) *Report {
+	report := &Report{Listeners: listeners}
+
+	for i := range fwRules {
+		rule := &fwRules[i]
+		if rule.Type == models.RuleTypeTrustIP || rule.Port == 0 {
+			continue
+		}
+		// Forwarded ports are handled before they reach local sockets
+		if forwarded(natRules, rule.Port, rule.Protocol) {
+			continue
+		}
+		if !listening(listeners, rule.Port, rule.Protocol, nil) {
+			report.Findings = append(report.Findings, Finding{Kind: KindNotListening, Rule: rule})
+		}
+	}
+
+	for i := range listeners {
+		l := &listeners[i]
+		if acceptAll || !l.Wildcard() || opened(fwRules, l.Port, l.Protocol) || opened(allowed, l.Port, l.Protocol) || forwarded(natRules, l.Port, l.Protocol) {
+			continue
+		}
+		report.Findings = append(report.Findings, Finding{Kind: KindFirewalled, Listener: l})
+	}
+
+	for i := range natRules {
+		rule := &natRules[i]
+		ip := net.ParseIP(rule.InternalIP)
+		if ip == nil || !isLocal(ip, local) {
+			report.Unchecked++
+			continue
+		}
+		if !listening(listeners, rule.InternalPort, rule.Proto, ip) {
+			report.Findings = append(report.Findings, Finding{Kind: KindDeadForward, NAT: rule})
+		}
+	}
+	return report
+}
+
+// listening reports whether a non-loopback socket (or, with ip set, one
+// accepting traffic for ip) listens on the port
+func listening(listeners []sockets.Listener, port int, proto models.Protocol, ip net.IP) bool {
+	for i := range listeners {
+		l := &listeners[i]
+		if l.Port != port || !sameProto(l.Protocol, proto) {
+			continue
+		}
+		if ip != nil {
+			if l.Accepts(ip) {
+				return true
+			}
+		} else if !l.Loopback() {
+			return true
+		}
+	}
+	return false
+}
+
+// opened reports whether a firewall rule opens the port to anyone
+func opened(fwRules []models.FirewallRule, port int, proto models.Protocol) bool {
+	for _, r := range fwRules {
+		if r.Type != models.RuleTypeTrustIP && r.Port == port && sameProto(r.Protocol, proto) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwarded reports whether a NAT rule forwards the external port
+func forwarded(natRules []models.NATRule, port int, proto models.Protocol) bool {
+	for _, r := range natRules {
+		if r.ExternalPort == port && sameProto(r.Proto, proto) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameProto(a, b models.Protocol) bool {
+	return strings.EqualFold(string(a), string(b))
+}
+
+// isLocal reports whether ip belongs to this host
+func isLocal(ip net.IP, local []net.IP) bool {
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, addr := range local {
+		if addr.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// localAddresses returns the addresses of this host's interfaces
+func localAddresses() []net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, ipnet.IP)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,70 @@
+// Package exposure joins the listening sockets of this host with the open
+// firewall rules and NAT forwards to find mismatches
+package exposure
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/sockets"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Kind classifies a finding
+type Kind string
+
+const (
+	// KindNotListening is an open port that nothing listens on
+	KindNotListening Kind = "not_listening"
+	// KindFirewalled is a service listening on all addresses whose port
+	// no rule opens
+	KindFirewalled Kind = "firewalled"
+	// KindDeadForward is a NAT forward to a local target that nothing
+	// listens on
+	KindDeadForward Kind = "dead_forward"
+)
+
+// Finding is one mismatch between sockets and rules
+type Finding struct {
+	Kind     Kind
+	Rule     *models.FirewallRule
+	NAT      *models.NATRule
+	Listener *sockets.Listener
+}
+
+// String returns a human-readable representation of the finding
+func (f Finding) String() string {
+	switch f.Kind {
+	case KindNotListening:
+		return fmt.Sprintf("port %d/%s is open but nothing listens on it", f.Rule.Port, f.Rule.Protocol)
+	case KindFirewalled:
+		return fmt.Sprintf("%s listens on all addresses but no rule opens it", f.Listener.String())
+	case KindDeadForward:
+		return fmt.Sprintf("forward %d/%s -> %s:%d has no listener", f.NAT.ExternalPort, f.NAT.Proto, f.NAT.InternalIP, f.NAT.InternalPort)
+	}
+	return string(f.Kind)
+}
+
+// Report lists the listeners and every finding
+type Report struct {
+	Listeners []sockets.Listener
+	Findings  []Finding
+	// Unchecked counts forwards to other hosts, whose listeners cannot be
+	// seen from here
+	Unchecked int
+}
+
+// Clean reports whether nothing needs attention
+func (r *Report) Clean() bool {
+	return len(r.Findings) == 0
+}
+
+// ByKind returns the findings of one kind
+func (r *Report) ByKind(kind Kind) []Finding {
+	var findings []Finding
+	for _, f := range r.Findings {
+		if f.Kind == kind {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,106 @@
+// Package follow keeps NAT rules bound to a container pointing at the
+// container's current IP address
+package follow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/container"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Change is a container NAT rule whose target moved
+type Change struct {
+	Rule  models.NATRule
+	NewIP string
+}
+
+// String returns a human-readable representation of the change
+func (c Change) String() string {
+	return fmt.Sprintf("%s (%s): %s -> %s", c.Rule.ID, c.Rule.Container, c.Rule.InternalIP, c.NewIP)
+}
+
+// Check compares the active container rules in state with the running
+// containers. Containers that are not running are left alone.
+func Check(ctx context.Context, stateMgr *state.Manager) ([]Change, error) {
+	var rules []models.NATRule
+	for _, r := range stateMgr.ListActiveRules() {
+		if r.IsNAT() && r.Container != "" {
+			rules = append(rules, r.NATRule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	containers, err := container.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ips := make(map[string]string)
+	for i := range containers {
+		ips[containers[i].Name] = containers[i].IP()
+	}
+
+	var changes []Change
+	for _, rule := range rules {
+		ip := ips[rule.Container]
+		if ip != "" && ip != rule.InternalIP {
+			changes = append(changes, Change{Rule: rule, NewIP: ip})
+		}
+	}
+	return changes, nil
+}
+
+// Update re-points the rules in one transaction, replacing each forward
+// under its existing ID so state and the audit journal keep its history
+func Update(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	// Committing under a change awaiting confirmation would leave its
+	// snapshot and revert out of date
+	pending, err := transaction.LoadPending()
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		return fmt.Errorf("container rules not followed: %w", drivers.ErrPendingChange)
+	}
+
+	txn := transaction.New(provider, stateMgr)
+	txn.SetAction(audit.ActionFollow)
+	for _, change := range changes {
+		updated := change.Rule
+		updated.InternalIP = change.NewIP
+		if err := txn.RemoveNAT(change.Rule); err != nil {
+			return err
+		}
+		if err := txn.ApplyNAT(updated); err != nil {
+			return err
+		}
+	}
+
+	result, err := txn.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	return result.JournalErr()
+}
+
+// Reconcile checks the container rules and updates the ones that moved
+func Reconcile(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager) ([]Change, error) {
+	changes, err := Check(ctx, stateMgr)
+	if err != nil {
+		return nil, err
+	}
+	if err := Update(ctx, provider, stateMgr, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
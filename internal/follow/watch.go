@@ -0,0 +1,48 @@
+package follow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/container"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+)
+
+// Watch reconciles the container rules once, then again whenever a
+// container starts, until ctx is cancelled. report is called with every
+// change applied and every error that did not stop the watch.
+func Watch(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, report func(changes []Change, err error)) error {
+	events, err := container.Events(ctx)
+	if err != nil {
+		return err
+	}
+
+	report(Reconcile(ctx, provider, stateMgr))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("container event streams ended")
+			}
+			if tracks(stateMgr, event) {
+				report(Reconcile(ctx, provider, stateMgr))
+			}
+		}
+	}
+}
+
+// tracks reports whether any active rule is bound to the event's container
+func tracks(stateMgr *state.Manager, event container.Event) bool {
+	for _, r := range stateMgr.ListActiveRules() {
+		if r.IsNAT() && r.Container != "" && r.Container == event.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// composeFile is the part of a docker-compose or podman-compose file that
+// describes published ports
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+type composeService struct {
+	Image string        `yaml:"image"`
+	Ports []composePort `yaml:"ports"`
+}
+
+// composePort accepts both the short "[ip:][published:]target[/proto]"
+// syntax and the long mapping syntax
+type composePort struct {
+	HostIP    string
+	Published string
+	Target    string
+	Protocol  string
+}
+
+// UnmarshalYAML decodes either port syntax
+func (p *composePort) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return p.parseShort(node.Value)
+	}
+	var long struct {
+		Target    string `yaml:"target"`
+		Published string `yaml:"published"`
+		Protocol  string `yaml:"protocol"`
+		HostIP    string `yaml:"host_ip"`
+	}
+	if err := node.Decode(&long); err != nil {
+		return err
+	}
+	*p = composePort{HostIP: long.HostIP, Published: long.Published, Target: long.Target, Protocol: long.Protocol}
+	return nil
+}
+
+func (p *composePort) parseShort(spec string) error {
+	if i := strings.LastIndex(spec, "/"); i >= 0 {
+		p.Protocol = spec[i+1:]
+		spec = spec[:i]
+	}
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]")
+		if end < 0 {
+			return fmt.Errorf("invalid port '%s'", spec)
+		}
+		p.HostIP = spec[1:end]
+		spec = strings.TrimPrefix(spec[end+1:], ":")
+	}
+
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 1:
+		p.Target = parts[0]
+	case 2:
+		p.Published, p.Target = parts[0], parts[1]
+	case 3:
+		p.HostIP, p.Published, p.Target = parts[0], parts[1], parts[2]
+	default:
+		return fmt.Errorf("invalid port '%s'", spec)
+	}
+	return nil
+}
+
+// parseCompose proposes an open port for every published port. The
+// container engine performs the DNAT itself, so only the host firewall
+// needs to admit the traffic.
+func parseCompose(data []byte, products Products) ([]models.AppliedRule, []string, error) {
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+
+	names := make([]string, 0, len(file.Services))
+	for name := range file.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rules []models.AppliedRule
+	var warnings []string
+	for _, name := range names {
+		service := file.Services[name]
+		product := products.ForService(name, service.Image)
+		for _, port := range service.Ports {
+			where := fmt.Sprintf("service %s port %s", name, port.Target)
+			if port.Published == "" {
+				warnings = append(warnings, where+": skipped, published on a random host port")
+				continue
+			}
+			if isLoopback(port.HostIP) {
+				warnings = append(warnings, where+": skipped, bound to "+port.HostIP+" only")
+				continue
+			}
+			proto := models.TCP
+			if port.Protocol != "" {
+				var ok bool
+				if proto, ok = parseProto(port.Protocol); !ok {
+					warnings = append(warnings, where+": skipped, unsupported protocol "+port.Protocol)
+					continue
+				}
+			}
+			published, err := parsePorts(port.Published)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: %v", where, err))
+				continue
+			}
+			for _, p := range published {
+				description := fmt.Sprintf("Compose service %s (container port %s)", name, port.Target)
+				rules = append(rules, newFirewallRule(product, p, proto, "", description))
+			}
+		}
+	}
+	return rules, warnings, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	return strings.HasPrefix(host, "127.") || host == "::1"
+}
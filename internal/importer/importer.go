@@ -0,0 +1,202 @@
+// Package importer converts existing iptables, nftables and compose
+// configurations into Portly rules so hosts can be onboarded
+package importer
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/orchestrator/unified-firewall/internal/bundle"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Source formats the importer understands
+const (
+	FormatIptables = "iptables-save"
+	FormatNft      = "nft-ruleset"
+	FormatCompose  = "compose"
+)
+
+// defaultProduct labels imported rules no known product claims
+const defaultProduct = "imported"
+
+// Products maps known product names to their default ports, used to label
+// imported rules
+type Products map[string][]int
+
+// Detect guesses the format of a configuration from its content
+func Detect(data []byte) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "*nat", line == "*filter", strings.HasPrefix(line, "-A "):
+			return FormatIptables, nil
+		case strings.HasPrefix(line, "table "):
+			return FormatNft, nil
+		case strings.HasPrefix(line, "services:"):
+			return FormatCompose, nil
+		}
+	}
+	return "", fmt.Errorf("unrecognized format: expected iptables-save output, an nft ruleset or a compose file")
+}
+
+// Parse converts a configuration into rules. The returned warnings list
+// what was skipped and why.
+func Parse(format string, data []byte, products Products) ([]models.AppliedRule, []string, error) {
+	switch format {
+	case FormatIptables:
+		rules, warnings := parseIptables(data, products)
+		return rules, warnings, nil
+	case FormatNft:
+		rules, warnings := parseNft(data, products)
+		return rules, warnings, nil
+	case FormatCompose:
+		return parseCompose(data, products)
+	}
+	return nil, nil, fmt.Errorf("unknown import format '%s'", format)
+}
+
+// Load reads a configuration file and wraps the proposed rules in a bundle
+// so they are previewed and applied like any other import
+func Load(path string, products Products) (*bundle.Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format, err := Detect(data)
+	if err != nil {
+		return nil, err
+	}
+	rules, warnings, err := Parse(format, data, products)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	return &bundle.Bundle{
+		Manifest: bundle.Manifest{
+			FormatVersion: bundle.FormatVersion,
+			CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+			Hostname:      hostname,
+			Provider:      format,
+			Format:        format,
+			NativeFile:    filepath.Base(path),
+		},
+		Rules:    rules,
+		Native:   data,
+		Warnings: warnings,
+	}, nil
+}
+
+// ForPort returns the one product whose default ports include port, or
+// the import default when none or several do
+func (p Products) ForPort(port int) string {
+	var matches []string
+	for name, ports := range p {
+		for _, candidate := range ports {
+			if candidate == port {
+				matches = append(matches, name)
+				break
+			}
+		}
+	}
+	if len(matches) != 1 {
+		return defaultProduct
+	}
+	return matches[0]
+}
+
+// ForService returns the product named by a compose service or its image,
+// falling back to the service name itself
+func (p Products) ForService(service, image string) string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	base := image
+	if i := strings.LastIndex(base, "/"); i >= 0 {
+		base = base[i+1:]
+	}
+	if i := strings.IndexAny(base, ":@"); i >= 0 {
+		base = base[:i]
+	}
+	for _, candidate := range []string{service, base} {
+		for _, name := range names {
+			if strings.EqualFold(candidate, name) {
+				return name
+			}
+		}
+	}
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(base), name) {
+			return name
+		}
+	}
+	return service
+}
+
+func newNATRule(product string, port int, ip string, target int, proto models.Protocol, description string) models.AppliedRule {
+	return models.NewAppliedNATRule(models.NATRule{
+		ID:           uuid.New().String()[:8],
+		Product:      product,
+		ExternalPort: port,
+		InternalIP:   ip,
+		InternalPort: target,
+		Proto:        proto,
+		Description:  description,
+	}, models.StatusPending)
+}
+
+func newFirewallRule(product string, port int, proto models.Protocol, source, description string) models.AppliedRule {
+	ruleType := models.RuleTypePort
+	switch {
+	case source != "" && port == 0:
+		ruleType = models.RuleTypeTrustIP
+	case source != "":
+		ruleType = models.RuleTypePortLimit
+	}
+	return models.NewAppliedFirewallRule(models.FirewallRule{
+		ID:          uuid.New().String()[:8],
+		Type:        ruleType,
+		Port:        port,
+		Protocol:    proto,
+		SourceIP:    source,
+		Description: description,
+		Product:     product,
+	}, models.StatusPending)
+}
+
+// hostAddress strips a single-host prefix length. Wider networks cannot
+// be represented as a source IP and yield "".
+func hostAddress(addr string) string {
+	if ip, network, err := net.ParseCIDR(addr); err == nil {
+		if ones, bits := network.Mask.Size(); ones != bits {
+			return ""
+		}
+		return ip.String()
+	}
+	if net.ParseIP(addr) == nil {
+		return ""
+	}
+	return addr
+}
+
+// parseProto returns the protocol for tcp or udp, and false otherwise
+func parseProto(s string) (models.Protocol, bool) {
+	switch strings.ToLower(s) {
+	case "tcp":
+		return models.TCP, true
+	case "udp":
+		return models.UDP, true
+	}
+	return "", false
+}
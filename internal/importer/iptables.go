@@ -0,0 +1,208 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// parsedRule is the subset of an iptables or nft rule Portly can represent
+type parsedRule struct {
+	chain       string
+	proto       string
+	ports       string
+	source      string
+	target      string
+	destination string
+	extra       bool
+}
+
+// parseIptables reads DNAT rules from the nat table and ACCEPT rules on
+// the INPUT chain of the filter table
+func parseIptables(data []byte, products Products) ([]models.AppliedRule, []string) {
+	var rules []models.AppliedRule
+	var warnings []string
+	table := ""
+	skipped := 0
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "*") {
+			table = strings.TrimPrefix(line, "*")
+			continue
+		}
+		if !strings.HasPrefix(line, "-A ") {
+			continue
+		}
+
+		r := parseIptablesLine(line)
+		switch {
+		case table == "nat" && r.target == "DNAT":
+			if strings.HasPrefix(r.chain, "DOCKER") {
+				warnings = append(warnings, fmt.Sprintf("line %d: skipped, chain %s is managed by Docker", n+1, r.chain))
+				continue
+			}
+			imported, err := r.natRules(products, "Imported from iptables")
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("line %d: %v", n+1, err))
+				continue
+			}
+			if imported == nil {
+				skipped++
+			}
+			rules = append(rules, imported...)
+
+		case table == "filter" && r.chain == "INPUT" && r.target == "ACCEPT":
+			imported, err := r.firewallRules(products, "Imported from iptables")
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("line %d: %v", n+1, err))
+				continue
+			}
+			if imported == nil {
+				skipped++
+			}
+			rules = append(rules, imported...)
+		}
+	}
+
+	if skipped > 0 {
+		warnings = append(warnings, fmt.Sprintf("skipped %d rule(s) with matches Portly cannot represent", skipped))
+	}
+	return rules, warnings
+}
+
+// parseIptablesLine picks the options Portly understands; any other
+// match, such as an interface or conntrack state, sets extra
+func parseIptablesLine(line string) parsedRule {
+	args := splitArgs(line)
+	var r parsedRule
+	for i := 0; i < len(args); i++ {
+		next := func() string {
+			if i+1 < len(args) {
+				i++
+				return args[i]
+			}
+			return ""
+		}
+		switch args[i] {
+		case "-A":
+			r.chain = next()
+		case "-p", "--protocol":
+			r.proto = next()
+		case "--dport", "--destination-port", "--dports", "--destination-ports":
+			r.ports = next()
+		case "-s", "--source":
+			r.source = next()
+		case "-j", "--jump":
+			r.target = next()
+		case "--to-destination":
+			r.destination = next()
+		case "-m", "--match":
+			// These modules only select the options above or annotate
+			if module := next(); module != "tcp" && module != "udp" && module != "multiport" && module != "comment" {
+				r.extra = true
+			}
+		case "--comment":
+			next()
+		default:
+			r.extra = true
+			if strings.HasPrefix(args[i], "-") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+			}
+		}
+	}
+	return r
+}
+
+// natRules returns nil, nil for rules that are not representable
+func (r parsedRule) natRules(products Products, description string) ([]models.AppliedRule, error) {
+	if r.extra {
+		return nil, nil
+	}
+	proto, ok := parseProto(r.proto)
+	if !ok || r.ports == "" {
+		return nil, fmt.Errorf("DNAT without a tcp/udp destination port is not supported")
+	}
+	ports, err := parsePorts(r.ports)
+	if err != nil {
+		return nil, err
+	}
+	ip, target := splitHostPort(r.destination)
+	if hostAddress(ip) == "" {
+		return nil, fmt.Errorf("DNAT destination '%s' is not a single address", r.destination)
+	}
+
+	var rules []models.AppliedRule
+	for _, port := range ports {
+		internal := port
+		if target != "" {
+			if internal, err = parsePort(target); err != nil {
+				return nil, err
+			}
+		}
+		rules = append(rules, newNATRule(products.ForPort(port), port, ip, internal, proto, description))
+	}
+	return rules, nil
+}
+
+// firewallRules returns nil, nil for rules that are not representable
+func (r parsedRule) firewallRules(products Products, description string) ([]models.AppliedRule, error) {
+	if r.extra {
+		return nil, nil
+	}
+	source := ""
+	if r.source != "" {
+		if source = hostAddress(r.source); source == "" {
+			return nil, fmt.Errorf("source '%s' is a network, only single addresses are supported", r.source)
+		}
+	}
+
+	if r.ports == "" {
+		if source == "" || r.proto != "" {
+			return nil, nil
+		}
+		return []models.AppliedRule{newFirewallRule(defaultProduct, 0, "", source, description)}, nil
+	}
+
+	proto, ok := parseProto(r.proto)
+	if !ok {
+		return nil, nil
+	}
+	ports, err := parsePorts(r.ports)
+	if err != nil {
+		return nil, err
+	}
+	var rules []models.AppliedRule
+	for _, port := range ports {
+		rules = append(rules, newFirewallRule(products.ForPort(port), port, proto, source, description))
+	}
+	return rules, nil
+}
+
+// splitArgs splits a rule into arguments, keeping quoted strings together
+func splitArgs(line string) []string {
+	var args []string
+	var current strings.Builder
+	quoted, started := false, false
+	for _, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			started = true
+		case c == ' ' && !quoted:
+			if started {
+				args = append(args, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(c)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, current.String())
+	}
+	return args
+}
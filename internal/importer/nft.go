@@ -0,0 +1,143 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// parseNft reads dnat rules from prerouting chains and accept rules from
+// input chains of an `nft list ruleset` listing
+func parseNft(data []byte, products Products) ([]models.AppliedRule, []string) {
+	var rules []models.AppliedRule
+	var warnings []string
+	hook := ""
+	skipped := 0
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := raw
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "chain "):
+			hook = ""
+			continue
+		case strings.HasPrefix(line, "type ") && strings.Contains(line, " hook "):
+			fields := strings.Fields(line)
+			for i, f := range fields {
+				if f == "hook" && i+1 < len(fields) {
+					hook = fields[i+1]
+				}
+			}
+			continue
+		}
+
+		r, ok := parseNftRule(line)
+		if !ok {
+			if (hook == "input" && strings.HasSuffix(line, "accept")) || (hook == "prerouting" && strings.Contains(line, "dnat")) {
+				skipped++
+			}
+			continue
+		}
+
+		var imported []models.AppliedRule
+		var err error
+		switch {
+		case hook == "prerouting" && r.destination != "":
+			imported, err = r.natRules(products, "Imported from nftables")
+		case hook == "input" && r.target == "ACCEPT":
+			imported, err = r.firewallRules(products, "Imported from nftables")
+		default:
+			continue
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("line %d: %v", n+1, err))
+			continue
+		}
+		if imported == nil {
+			skipped++
+			continue
+		}
+		rules = append(rules, imported...)
+	}
+
+	if skipped > 0 {
+		warnings = append(warnings, fmt.Sprintf("skipped %d rule(s) with matches Portly cannot represent", skipped))
+	}
+	return rules, warnings
+}
+
+// parseNftRule maps a rule of the forms Portly writes itself onto the same
+// representation as iptables rules. It reports false for anything else.
+func parseNftRule(line string) (parsedRule, bool) {
+	tokens := nftTokens(line)
+	var r parsedRule
+	for i := 0; i < len(tokens); i++ {
+		next := func() string {
+			if i+1 < len(tokens) {
+				i++
+				return tokens[i]
+			}
+			return ""
+		}
+		switch tok := tokens[i]; tok {
+		case "ip":
+			switch next() {
+			case "saddr":
+				r.source = next()
+			case "to":
+				// "dnat ip to" in inet tables
+				r.destination = next()
+			default:
+				return r, false
+			}
+		case "tcp", "udp":
+			if next() != "dport" {
+				return r, false
+			}
+			r.proto = tok
+			r.ports = next()
+		case "counter":
+			// Counter values may follow the keyword
+			for i+2 < len(tokens) && (tokens[i+1] == "packets" || tokens[i+1] == "bytes") {
+				i += 2
+			}
+		case "accept":
+			r.target = "ACCEPT"
+		case "dnat":
+			r.target = "DNAT"
+			if i+1 < len(tokens) && tokens[i+1] == "to" {
+				i++
+				r.destination = next()
+			}
+		case "comment":
+			next()
+		default:
+			return r, false
+		}
+	}
+	return r, r.target != ""
+}
+
+// nftTokens splits a rule, collapsing anonymous sets like "{ 80, 443 }"
+// into a single "80,443" token
+func nftTokens(line string) []string {
+	var tokens []string
+	fields := strings.Fields(line)
+	for i := 0; i < len(fields); i++ {
+		if fields[i] != "{" {
+			tokens = append(tokens, strings.Trim(fields[i], `"`))
+			continue
+		}
+		var members []string
+		for i++; i < len(fields) && fields[i] != "}"; i++ {
+			members = append(members, strings.TrimSuffix(fields[i], ","))
+		}
+		tokens = append(tokens, strings.Join(members, ","))
+	}
+	return tokens
+}
@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRangePorts caps how many rules a single port range expands into
+const maxRangePorts = 64
+
+// parsePorts expands a port list such as "80,443", "8000:8010" or
+// "8000-8010" into individual ports
+func parsePorts(spec string) ([]int, error) {
+	var ports []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi := part, part
+		if i := strings.IndexAny(part, ":-"); i > 0 {
+			lo, hi = part[:i], part[i+1:]
+		}
+		first, err := parsePort(lo)
+		if err != nil {
+			return nil, err
+		}
+		last, err := parsePort(hi)
+		if err != nil {
+			return nil, err
+		}
+		if last < first {
+			return nil, fmt.Errorf("invalid port range '%s'", part)
+		}
+		if last-first+1 > maxRangePorts {
+			return nil, fmt.Errorf("port range '%s' is larger than %d ports", part, maxRangePorts)
+		}
+		for port := first; port <= last; port++ {
+			ports = append(ports, port)
+		}
+	}
+	return ports, nil
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port '%s'", s)
+	}
+	return port, nil
+}
+
+// splitHostPort splits "ip[:port]" and "[ipv6][:port]" destinations
+func splitHostPort(s string) (string, string) {
+	if strings.HasPrefix(s, "[") {
+		if end := strings.Index(s, "]"); end > 0 {
+			return s[1:end], strings.TrimPrefix(s[end+1:], ":")
+		}
+	}
+	if strings.Count(s, ":") == 1 {
+		i := strings.Index(s, ":")
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
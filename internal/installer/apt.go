@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 )
 
-func (i *Installer) installWithAPT(ctx context.Context, config ProductConfig) error {
+func (i *Installer) installWithAPT(ctx context.Context, config catalog.Product) error {
 	fmt.Println("Updating package list...")
 	cmd := exec.CommandContext(ctx, "apt-get", "update")
 	cmd.Stdout = os.Stdout
@@ -25,7 +27,7 @@ func (i *Installer) installWithAPT(ctx context.Context, config ProductConfig) er
 		}
 	}
 
-	cmd = exec.CommandContext(ctx, "apt-get", "install", "-y", config.PackageName)
+	cmd = exec.CommandContext(ctx, "apt-get", "install", "-y", config.PackageFor("apt"))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
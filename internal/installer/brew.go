@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 )
 
-func (i *Installer) installWithBrew(ctx context.Context, config ProductConfig) error {
+func (i *Installer) installWithBrew(ctx context.Context, config catalog.Product) error {
 	if _, err := exec.LookPath("brew"); err != nil {
 		return fmt.Errorf("Homebrew not installed: https://brew.sh")
 	}
@@ -20,12 +22,7 @@ func (i *Installer) installWithBrew(ctx context.Context, config ProductConfig) e
 		fmt.Printf("Warning: brew update failed: %v\n", err)
 	}
 
-	formula := config.BrewFormula
-	if formula == "" {
-		formula = config.PackageName
-	}
-
-	cmd = exec.CommandContext(ctx, "brew", "install", formula)
+	cmd = exec.CommandContext(ctx, "brew", "install", config.PackageFor("brew"))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
@@ -27,12 +28,12 @@ func NewInstaller(autoAccept bool) (*Installer, error) {
 
 // CheckProduct checks if a product is installed and prompts for installation
 func (i *Installer) CheckProduct(ctx context.Context, productName string) (models.ProductInfo, error) {
-	config, supported := SupportedProducts[productName]
-	if !supported {
+	config, supported := catalog.Default().Get(productName)
+	if !supported || !config.Installable() {
 		return i.checkBinary(ctx, productName)
 	}
 
-	path, exists := platform.IsProductInstalled(productName)
+	path, exists := platform.IsProductInstalled(config.BinaryName())
 	if exists {
 		return models.ProductInfo{
 			Name:        productName,
@@ -54,17 +55,17 @@ func (i *Installer) CheckProduct(ctx context.Context, productName string) (model
 		}
 	}
 
-	fmt.Printf("Installing %s...\n", config.DisplayName)
+	fmt.Printf("Installing %s...\n", config.Title())
 	if err := i.InstallProduct(ctx, config); err != nil {
 		return models.ProductInfo{}, fmt.Errorf("failed to install %s: %w", productName, err)
 	}
 
-	path, exists = platform.IsProductInstalled(productName)
+	path, exists = platform.IsProductInstalled(config.BinaryName())
 	if !exists {
 		return models.ProductInfo{}, fmt.Errorf("installation completed but %s not found", productName)
 	}
 
-	fmt.Printf("✓ %s installed at %s\n", config.DisplayName, path)
+	fmt.Printf("✓ %s installed at %s\n", config.Title(), path)
 
 	return models.ProductInfo{
 		Name:        productName,
@@ -73,10 +74,10 @@ func (i *Installer) CheckProduct(ctx context.Context, productName string) (model
 	}, nil
 }
 
-func (i *Installer) promptUser(config ProductConfig) (bool, error) {
+func (i *Installer) promptUser(config catalog.Product) (bool, error) {
 	pm := i.osInfo.PackageManager()
 
-	fmt.Printf("\n⚠️  '%s' is not installed.\n", config.DisplayName)
+	fmt.Printf("\n⚠️  '%s' is not installed.\n", config.Title())
 	fmt.Printf("Install using %s? (y/n): ", pm)
 
 	reader := bufio.NewReader(os.Stdin)
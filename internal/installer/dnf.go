@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 )
 
-func (i *Installer) installWithDNF(ctx context.Context, config ProductConfig) error {
+func (i *Installer) installWithDNF(ctx context.Context, config catalog.Product) error {
 	for _, dep := range config.Dependencies {
 		cmd := exec.CommandContext(ctx, "dnf", "install", "-y", dep)
 		cmd.Stdout = os.Stdout
@@ -17,7 +19,7 @@ func (i *Installer) installWithDNF(ctx context.Context, config ProductConfig) er
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "dnf", "install", "-y", config.PackageName)
+	cmd := exec.CommandContext(ctx, "dnf", "install", "-y", config.PackageFor("dnf"))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -27,7 +29,7 @@ func (i *Installer) installWithDNF(ctx context.Context, config ProductConfig) er
 	return i.runPostInstall(ctx, config)
 }
 
-func (i *Installer) installWithYUM(ctx context.Context, config ProductConfig) error {
+func (i *Installer) installWithYUM(ctx context.Context, config catalog.Product) error {
 	for _, dep := range config.Dependencies {
 		cmd := exec.CommandContext(ctx, "yum", "install", "-y", dep)
 		cmd.Stdout = os.Stdout
@@ -37,7 +39,7 @@ func (i *Installer) installWithYUM(ctx context.Context, config ProductConfig) er
 		}
 	}
 
-	cmd := exec.CommandContext(ctx, "yum", "install", "-y", config.PackageName)
+	cmd := exec.CommandContext(ctx, "yum", "install", "-y", config.PackageFor("yum"))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
@@ -7,11 +7,16 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 )
 
 // InstallProduct installs a product using the appropriate package manager
-func (i *Installer) InstallProduct(ctx context.Context, config ProductConfig) error {
+func (i *Installer) InstallProduct(ctx context.Context, config catalog.Product) error {
 	pm := i.osInfo.PackageManager()
+	if config.PackageFor(pm) == "" {
+		return fmt.Errorf("no %s package known for %s", pm, config.Name)
+	}
 
 	switch pm {
 	case "dnf":
@@ -27,7 +32,7 @@ func (i *Installer) InstallProduct(ctx context.Context, config ProductConfig) er
 	}
 }
 
-func (i *Installer) runPostInstall(ctx context.Context, config ProductConfig) error {
+func (i *Installer) runPostInstall(ctx context.Context, config catalog.Product) error {
 	for _, cmdStr := range config.PostInstall {
 		fmt.Printf("Running post-install: %s\n", cmdStr)
 
@@ -1,52 +1,22 @@
 package installer
 
-// SupportedProducts defines configurations for supported products
-var SupportedProducts = map[string]ProductConfig{
-	"docker": {
-		Name:         "docker",
-		DisplayName:  "Docker",
-		PackageName:  "docker-ce",
-		BrewFormula:  "docker",
-		Dependencies: []string{"docker-compose"},
-	},
-	"podman": {
-		Name:         "podman",
-		DisplayName:  "Podman",
-		PackageName:  "podman",
-		BrewFormula:  "podman",
-		Dependencies: []string{"podman-compose"},
-	},
-	"tailscale": {
-		Name:        "tailscale",
-		DisplayName: "Tailscale",
-		PackageName: "tailscale",
-		BrewFormula: "tailscale",
-	},
-	"headscale": {
-		Name:        "headscale",
-		DisplayName: "Headscale",
-		PackageName: "headscale",
-		BrewFormula: "headscale",
-	},
-	"twingate": {
-		Name:        "twingate",
-		DisplayName: "Twingate",
-		PackageName: "twingate",
-		BrewFormula: "twingate",
-	},
-}
+import (
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+)
 
-// GetSupportedProducts returns a list of supported product names
+// GetSupportedProducts returns the catalog products that can be installed
 func GetSupportedProducts() []string {
-	products := make([]string, 0, len(SupportedProducts))
-	for name := range SupportedProducts {
-		products = append(products, name)
+	var products []string
+	for _, p := range catalog.Default().Products() {
+		if p.Installable() {
+			products = append(products, p.Name)
+		}
 	}
 	return products
 }
 
-// IsProductSupported checks if a product is in the supported list
+// IsProductSupported checks if a product can be installed from the catalog
 func IsProductSupported(name string) bool {
-	_, ok := SupportedProducts[name]
-	return ok
+	p, ok := catalog.Default().Get(name)
+	return ok && p.Installable()
 }
@@ -9,14 +9,3 @@ type Installer struct {
 	osInfo     *platform.OSInfo
 	autoAccept bool
 }
-
-// ProductConfig contains installation configuration for a product
-type ProductConfig struct {
-	Name         string
-	DisplayName  string
-	PackageName  string
-	BrewFormula  string
-	Repositories []string
-	Dependencies []string
-	PostInstall  []string
-}
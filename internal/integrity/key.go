@@ -0,0 +1,91 @@
+// Package integrity seals Portly's state and audit journal with an HMAC so
+// that edits made outside Portly can be detected
+package integrity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// Key is the secret used to seal state and journal entries
+type Key []byte
+
+// ErrKeyExposed is returned when the key file is readable by non-root users
+var ErrKeyExposed = errors.New("integrity key is readable by other users")
+
+// LoadKey reads the root-only key, creating it on first use. created
+// reports whether the key did not exist before, in which case nothing can
+// have been sealed with it yet.
+func LoadKey() (key Key, created bool, err error) {
+	path := platform.GetIntegrityKeyPath()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createKey(path)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read integrity key: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, false, fmt.Errorf("%w (%s has mode %s)", ErrKeyExposed, path, info.Mode().Perm())
+	}
+
+	key, err = hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) < 32 {
+		return nil, false, fmt.Errorf("integrity key %s is malformed", path)
+	}
+	return key, false, nil
+}
+
+func createKey(path string) (Key, bool, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, false, fmt.Errorf("failed to generate integrity key: %w", err)
+	}
+
+	if err := platform.EnsureStateDir(); err != nil {
+		return nil, false, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0400)
+	if errors.Is(err, os.ErrExist) {
+		// Another process created it first
+		key, _, err := LoadKey()
+		return key, false, err
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create integrity key: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		return nil, false, fmt.Errorf("failed to write integrity key: %w", err)
+	}
+	return key, true, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of the given parts
+func (k Key) Sign(parts ...[]byte) string {
+	mac := hmac.New(sha256.New, k)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time
+func (k Key) Verify(signature string, parts ...[]byte) bool {
+	return hmac.Equal([]byte(signature), []byte(k.Sign(parts...)))
+}
@@ -0,0 +1,71 @@
+package platform
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// CommandRecord is a system command run on behalf of a change
+type CommandRecord struct {
+	Command string `json:"command"`
+	Input   string `json:"input,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// CommandRecorder collects the commands run under a context
+type CommandRecorder struct {
+	mu      sync.Mutex
+	records []CommandRecord
+}
+
+type recorderKey struct{}
+
+// WithCommandRecorder returns a context whose mutating driver commands are
+// collected by the returned recorder
+func WithCommandRecorder(ctx context.Context) (context.Context, *CommandRecorder) {
+	rec := &CommandRecorder{}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// RecordCommand notes a finished command if ctx carries a recorder
+func RecordCommand(ctx context.Context, cmd *exec.Cmd, output []byte, err error) {
+	RecordScript(ctx, cmd, "", output, err)
+}
+
+// RecordScript notes a finished command that read input from stdin
+func RecordScript(ctx context.Context, cmd *exec.Cmd, input string, output []byte, err error) {
+	addRecord(ctx, CommandRecord{
+		Command: strings.Join(cmd.Args, " "),
+		Input:   input,
+		Output:  strings.TrimSpace(string(output)),
+	}, err)
+}
+
+// RecordFileWrite notes a file written directly rather than by a command
+func RecordFileWrite(ctx context.Context, path, content string, err error) {
+	addRecord(ctx, CommandRecord{Command: "write " + path, Input: content}, err)
+}
+
+// addRecord appends a finished command to the recorder ctx carries, if any
+func addRecord(ctx context.Context, record CommandRecord, err error) {
+	rec, ok := ctx.Value(recorderKey{}).(*CommandRecorder)
+	if !ok {
+		return
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	rec.mu.Lock()
+	rec.records = append(rec.records, record)
+	rec.mu.Unlock()
+}
+
+// Records returns the commands recorded so far
+func (r *CommandRecorder) Records() []CommandRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CommandRecord(nil), r.records...)
+}
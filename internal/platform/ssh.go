@@ -0,0 +1,46 @@
+package platform
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SSHConnection describes the SSH session the process is running under
+type SSHConnection struct {
+	ClientIP   string
+	ClientPort int
+	ServerIP   string
+	ServerPort int
+}
+
+// CurrentSSHConnection parses SSH_CONNECTION, returning nil when the
+// process is not running inside an SSH session
+func CurrentSSHConnection() *SSHConnection {
+	return ParseSSHConnection(os.Getenv("SSH_CONNECTION"))
+}
+
+// ParseSSHConnection parses the "client_ip client_port server_ip server_port"
+// format used by sshd
+func ParseSSHConnection(value string) *SSHConnection {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return nil
+	}
+
+	clientPort, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil
+	}
+	serverPort, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil
+	}
+
+	return &SSHConnection{
+		ClientIP:   fields[0],
+		ClientPort: clientPort,
+		ServerIP:   fields[2],
+		ServerPort: serverPort,
+	}
+}
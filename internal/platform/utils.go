@@ -37,8 +37,50 @@ func GetStateFilePath() string {
 	return filepath.Join(GetStateDir(), "state.json")
 }
 
+// GetConfigFilePath returns the path of the host configuration file
+func GetConfigFilePath() string {
+	return "/etc/portly/portly.yaml"
+}
+
+// GetProductsDir returns the directory of product catalog drop-in files
+func GetProductsDir() string {
+	return "/etc/portly/products.d"
+}
+
+// GetStateDBPath returns the path of the embedded state database
+func GetStateDBPath() string {
+	return filepath.Join(GetStateDir(), "state.db")
+}
+
+// GetIntegrityKeyPath returns the path of the root-only key sealing state
+// and the audit journal
+func GetIntegrityKeyPath() string {
+	return filepath.Join(GetStateDir(), "integrity.key")
+}
+
+// GetPendingFilePath returns the path of the unconfirmed-change record
+func GetPendingFilePath() string {
+	return filepath.Join(GetStateDir(), "pending.json")
+}
+
 // IsProductInstalled checks if a product binary exists in PATH
 func IsProductInstalled(name string) (string, bool) {
 	path, err := exec.LookPath(name)
 	return path, err == nil
 }
+
+// GetAuditFilePath returns the path of the append-only audit journal
+func GetAuditFilePath() string {
+	return filepath.Join(GetStateDir(), "audit.jsonl")
+}
+
+// GetBackupDir returns the directory backups are written to by default
+func GetBackupDir() string {
+	return filepath.Join(GetStateDir(), "backups")
+}
+
+// GetExportDir returns the directory rule export bundles are written to
+// and imported from
+func GetExportDir() string {
+	return filepath.Join(GetStateDir(), "exports")
+}
@@ -70,6 +70,7 @@ func (m *Manager) LoadAppArmorProfile(ctx context.Context, profileName, content
 
 	cmd := exec.CommandContext(ctx, "apparmor_parser", "-r", profilePath)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to load profile: %w (output: %s)", err, string(output))
 	}
@@ -87,6 +88,7 @@ func (m *Manager) UnloadAppArmorProfile(ctx context.Context, profileName string)
 
 	cmd := exec.CommandContext(ctx, "apparmor_parser", "-R", profilePath)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil && !strings.Contains(string(output), "does not exist") {
 		return fmt.Errorf("failed to unload profile: %w (output: %s)", err, string(output))
 	}
@@ -0,0 +1,155 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// AuditLogPath is where auditd writes AVC records
+const AuditLogPath = "/var/log/audit/audit.log"
+
+// ListAVCDenials returns the name_bind and name_connect denials in the
+// audit log. ausearch is used when installed since it also reads rotated
+// logs; otherwise the current log is read directly.
+func (m *Manager) ListAVCDenials(ctx context.Context) ([]AVCDenial, error) {
+	if !m.osInfo.IsRHEL() {
+		return nil, nil
+	}
+
+	if _, err := exec.LookPath("ausearch"); err == nil {
+		cmd := exec.CommandContext(ctx, "ausearch", "-m", "AVC", "--raw")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			// ausearch exits non-zero when nothing matches
+			if strings.Contains(string(output), "<no matches>") {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to search audit log: %w (output: %s)", err, string(output))
+		}
+		return parseAVCDenials(strings.NewReader(string(output)))
+	}
+
+	f, err := os.Open(AuditLogPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer f.Close()
+	return parseAVCDenials(f)
+}
+
+// parseAVCDenials reads raw audit records, whose AVC lines look like
+// type=AVC msg=audit(1700000000.123:456): avc:  denied  { name_bind } for
+// pid=1234 comm="redis-server" src=6380 scontext=system_u:system_r:redis_t:s0
+// tcontext=system_u:object_r:unreserved_port_t:s0 tclass=tcp_socket permissive=0
+func parseAVCDenials(r io.Reader) ([]AVCDenial, error) {
+	var denials []AVCDenial
+	index := make(map[AVCDenial]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		denial, ok := parseAVCLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		last := denial.Last
+		denial.Last = time.Time{}
+		if i, seen := index[denial]; seen {
+			denials[i].Count++
+			if last.After(denials[i].Last) {
+				denials[i].Last = last
+			}
+			continue
+		}
+		index[denial] = len(denials)
+		denial.Count = 1
+		denial.Last = last
+		denials = append(denials, denial)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit records: %w", err)
+	}
+	return denials, nil
+}
+
+// parseAVCLine extracts a port denial from one audit record
+func parseAVCLine(line string) (AVCDenial, bool) {
+	if !strings.Contains(line, "avc:") || !strings.Contains(line, "denied") {
+		return AVCDenial{}, false
+	}
+
+	var denial AVCDenial
+	if open := strings.Index(line, "{"); open >= 0 {
+		if end := strings.Index(line[open:], "}"); end > 0 {
+			for _, perm := range strings.Fields(line[open+1 : open+end]) {
+				if perm == "name_bind" || perm == "name_connect" {
+					denial.Permission = perm
+				}
+			}
+		}
+	}
+	if denial.Permission == "" {
+		return AVCDenial{}, false
+	}
+
+	for _, field := range strings.Fields(line) {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "msg":
+			denial.Last = parseAuditTime(value)
+		case "comm":
+			denial.Command = strings.Trim(value, `"`)
+		case "src", "dest":
+			denial.Port, _ = strconv.Atoi(value)
+		case "scontext":
+			denial.SourceType = contextType(value)
+		case "tcontext":
+			denial.TargetType = contextType(value)
+		case "tclass":
+			denial.Proto = models.Protocol(strings.TrimSuffix(value, "_socket"))
+		case "permissive":
+			denial.Permissive = value == "1"
+		}
+	}
+	if denial.Port == 0 {
+		return AVCDenial{}, false
+	}
+	return denial, true
+}
+
+// parseAuditTime reads the timestamp of "audit(1700000000.123:456):"
+func parseAuditTime(value string) time.Time {
+	value = strings.TrimPrefix(value, "audit(")
+	seconds, _, _ := strings.Cut(value, ".")
+	n, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
+
+// contextType returns the type of a context such as
+// system_u:system_r:httpd_t:s0
+func contextType(context string) string {
+	parts := strings.Split(context, ":")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
-// ApplySecurityPolicy applies the appropriate security policy based on OS
+// ApplySecurityPolicy applies the appropriate security policy based on OS.
+// Ports are labeled with the type and protocols the catalog lists for the
+// product; ports the catalog does not list get the product's port type
+// for TCP, and are not labeled at all for products without one.
 func (m *Manager) ApplySecurityPolicy(ctx context.Context, product string, policy models.SecurityPolicy, ports []int) error {
 	if m.osInfo.IsRHEL() {
 		for _, boolean := range policy.SelinuxBooleans {
@@ -16,8 +20,8 @@ func (m *Manager) ApplySecurityPolicy(ctx context.Context, product string, polic
 			}
 		}
 
-		for _, port := range ports {
-			if err := m.AddSELinuxPort(ctx, port, models.TCP, ""); err != nil {
+		for _, label := range portLabels(product, ports) {
+			if err := m.LabelPort(ctx, product, label.Port, label.Protocol, label.SELinuxType); err != nil {
 				return fmt.Errorf("failed to add SELinux port: %w", err)
 			}
 		}
@@ -37,12 +41,13 @@ func (m *Manager) ApplySecurityPolicy(ctx context.Context, product string, polic
 	return nil
 }
 
-// RemoveSecurityPolicy removes security policies for a product
+// RemoveSecurityPolicy removes security policies for a product. Only port
+// labels Portly recorded adding are removed.
 func (m *Manager) RemoveSecurityPolicy(ctx context.Context, product string, policy models.SecurityPolicy, ports []int) error {
 	if m.osInfo.IsRHEL() {
 		for _, port := range ports {
-			m.RemoveSELinuxPort(ctx, port, models.TCP)
-			m.RemoveSELinuxPort(ctx, port, models.UDP)
+			m.RemovePortLabel(ctx, port, models.TCP)
+			m.RemovePortLabel(ctx, port, models.UDP)
 		}
 	}
 
@@ -52,3 +57,29 @@ func (m *Manager) RemoveSecurityPolicy(ctx context.Context, product string, poli
 
 	return nil
 }
+
+// portLabels returns the labels a product's ports need, one per protocol
+func portLabels(name string, ports []int) []catalog.Port {
+	product, ok := catalog.Default().Get(name)
+	if !ok {
+		return nil
+	}
+
+	var labels []catalog.Port
+	for _, port := range ports {
+		listed := false
+		for _, p := range product.Ports {
+			if p.Port != port {
+				continue
+			}
+			listed = true
+			if selinuxType := product.SELinuxType(p); selinuxType != "" {
+				labels = append(labels, catalog.Port{Port: port, Protocol: p.Protocol, SELinuxType: selinuxType})
+			}
+		}
+		if !listed && product.Security.SELinuxPortType != "" {
+			labels = append(labels, catalog.Port{Port: port, Protocol: models.TCP, SELinuxType: product.Security.SELinuxPortType})
+		}
+	}
+	return labels
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -46,6 +47,7 @@ func (m *Manager) SetSELinuxBoolean(ctx context.Context, name string, value bool
 
 	cmd := exec.CommandContext(ctx, "setsebool", "-P", name, valStr)
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 	if err != nil {
 		return fmt.Errorf("failed to set SELinux boolean %s: %w (output: %s)", name, err, string(output))
 	}
@@ -53,19 +55,21 @@ func (m *Manager) SetSELinuxBoolean(ctx context.Context, name string, value bool
 	return nil
 }
 
-// AddSELinuxPort adds a port label for SELinux
+// AddSELinuxPort adds a port label for SELinux without recording it; use
+// LabelPort for labels Portly should own
 func (m *Manager) AddSELinuxPort(ctx context.Context, port int, proto models.Protocol, selinuxType string) error {
 	if !m.osInfo.IsRHEL() {
 		return nil
 	}
 
 	if selinuxType == "" {
-		selinuxType = "http_port_t"
+		return fmt.Errorf("no SELinux port type for %d/%s", port, proto)
 	}
 
 	protoStr := strings.ToLower(string(proto))
 	cmd := exec.CommandContext(ctx, "semanage", "port", "-a", "-t", selinuxType, "-p", protoStr, fmt.Sprintf("%d", port))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 
 	if err != nil && !strings.Contains(string(output), "already defined") {
 		return fmt.Errorf("failed to add SELinux port: %w (output: %s)", err, string(output))
@@ -83,6 +87,7 @@ func (m *Manager) RemoveSELinuxPort(ctx context.Context, port int, proto models.
 	protoStr := strings.ToLower(string(proto))
 	cmd := exec.CommandContext(ctx, "semanage", "port", "-d", "-p", protoStr, fmt.Sprintf("%d", port))
 	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
 
 	if err != nil && !strings.Contains(string(output), "does not exist") {
 		return fmt.Errorf("failed to remove SELinux port: %w (output: %s)", err, string(output))
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+)
+
+// ListSELinuxBooleans returns every boolean in the loaded policy with its
+// description and the catalog products recommending it. Without semanage
+// the booleans are read from getsebool, which gives no defaults or
+// descriptions.
+func (m *Manager) ListSELinuxBooleans(ctx context.Context) ([]SELinuxBoolean, error) {
+	if !m.osInfo.IsRHEL() {
+		return nil, nil
+	}
+
+	var booleans []SELinuxBoolean
+	if _, err := exec.LookPath("semanage"); err == nil {
+		cmd := exec.CommandContext(ctx, "semanage", "boolean", "-l")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SELinux booleans: %w (output: %s)", err, string(output))
+		}
+		booleans = parseSELinuxBooleans(string(output))
+	} else {
+		cmd := exec.CommandContext(ctx, "getsebool", "-a")
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SELinux booleans: %w (output: %s)", err, string(output))
+		}
+		booleans = parseGetsebool(string(output))
+	}
+
+	recommended := RecommendedBooleans()
+	for i := range booleans {
+		booleans[i].Products = recommended[booleans[i].Name]
+	}
+	return booleans, nil
+}
+
+// RecommendedBooleans maps each boolean the catalog lists to the products
+// that need it
+func RecommendedBooleans() map[string][]string {
+	recommended := make(map[string][]string)
+	for _, product := range catalog.Default().Products() {
+		for _, boolean := range product.Security.SELinuxBooleans {
+			recommended[boolean] = append(recommended[boolean], product.Name)
+		}
+	}
+	return recommended
+}
+
+// parseSELinuxBooleans reads `semanage boolean -l` output, whose lines
+// look like "httpd_can_network_connect (off  ,  off)  Allow httpd to ..."
+func parseSELinuxBooleans(output string) []SELinuxBoolean {
+	var booleans []SELinuxBoolean
+	for _, line := range strings.Split(output, "\n") {
+		name, rest, ok := strings.Cut(strings.TrimSpace(line), "(")
+		if !ok {
+			continue
+		}
+		states, description, ok := strings.Cut(rest, ")")
+		if !ok {
+			continue
+		}
+		current, def, ok := strings.Cut(states, ",")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || strings.Contains(name, " ") {
+			continue
+		}
+		booleans = append(booleans, SELinuxBoolean{
+			Name:        name,
+			On:          strings.TrimSpace(current) == "on",
+			Default:     strings.TrimSpace(def) == "on",
+			Description: strings.TrimSpace(description),
+		})
+	}
+	return booleans
+}
+
+// parseGetsebool reads `getsebool -a` output, whose lines look like
+// "httpd_can_network_connect --> off"
+func parseGetsebool(output string) []SELinuxBoolean {
+	var booleans []SELinuxBoolean
+	for _, line := range strings.Split(output, "\n") {
+		name, state, ok := strings.Cut(strings.TrimSpace(line), "-->")
+		if !ok {
+			continue
+		}
+		booleans = append(booleans, SELinuxBoolean{
+			Name: strings.TrimSpace(name),
+			On:   strings.TrimSpace(state) == "on",
+		})
+	}
+	return booleans
+}
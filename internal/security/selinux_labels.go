@@ -0,0 +1,137 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// SetStore sets where port labels and policies are recorded. Without a
+// store labels are still set, but RemovePortLabel never removes any.
+func (m *Manager) SetStore(store Store) {
+	m.store = store
+}
+
+// LabelPort gives a port the SELinux type a product needs. Ports the
+// policy already assigns that type are left alone; a local label of
+// another type is modified, and anything else is added. What Portly
+// changed is recorded so that RemovePortLabel can undo exactly that.
+func (m *Manager) LabelPort(ctx context.Context, product string, port int, proto models.Protocol, selinuxType string) error {
+	if !m.osInfo.IsRHEL() {
+		return nil
+	}
+	if selinuxType == "" {
+		return fmt.Errorf("no SELinux port type for %d/%s", port, proto)
+	}
+	proto = models.Protocol(strings.ToLower(string(proto)))
+
+	local, err := m.ListLocalSELinuxPorts(ctx)
+	if err != nil {
+		return err
+	}
+	record := models.PortLabelRecord{Port: port, Proto: proto, Type: selinuxType, Product: product}
+	if current := findLabel(local, port, proto); current != nil {
+		if current.Type == selinuxType {
+			return nil
+		}
+		// A label Portly added or changed before keeps its original type
+		record.Previous = current.Type
+		if m.store != nil {
+			if owned := m.store.GetPortLabel(port, proto); owned != nil {
+				record.Previous = owned.Previous
+			}
+		}
+		if err := m.semanagePort(ctx, "-m", port, proto, selinuxType); err != nil {
+			return err
+		}
+		return m.recordLabel(record)
+	}
+
+	ranges, err := m.ListSELinuxPortRanges(ctx)
+	if err != nil {
+		return err
+	}
+	if SELinuxPortType(ranges, port, proto) == selinuxType {
+		return nil
+	}
+	// A port the policy assigns to another type needs a local modification,
+	// which deleting later reverts just like an added label
+	action := "-a"
+	if policyDefinesPort(ranges, port, proto) {
+		action = "-m"
+	}
+	if err := m.semanagePort(ctx, action, port, proto, selinuxType); err != nil {
+		return err
+	}
+	return m.recordLabel(record)
+}
+
+// policyDefinesPort reports whether the policy labels exactly this port,
+// which semanage refuses to add again
+func policyDefinesPort(ranges []PortRange, port int, proto models.Protocol) bool {
+	for _, r := range ranges {
+		if r.Low == port && r.High == port && strings.EqualFold(string(r.Proto), string(proto)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemovePortLabel undoes a label Portly recorded: added labels are
+// deleted and modified ones get their previous type back. Ports Portly
+// did not label are left alone.
+func (m *Manager) RemovePortLabel(ctx context.Context, port int, proto models.Protocol) error {
+	if !m.osInfo.IsRHEL() || m.store == nil {
+		return nil
+	}
+	proto = models.Protocol(strings.ToLower(string(proto)))
+	record := m.store.GetPortLabel(port, proto)
+	if record == nil {
+		return nil
+	}
+
+	if record.Previous != "" {
+		if err := m.semanagePort(ctx, "-m", port, proto, record.Previous); err != nil {
+			return err
+		}
+	} else if err := m.RemoveSELinuxPort(ctx, port, proto); err != nil {
+		return err
+	}
+	return m.store.ForgetPortLabel(port, proto)
+}
+
+// recordLabel stores a label if a store is set
+func (m *Manager) recordLabel(record models.PortLabelRecord) error {
+	if m.store == nil {
+		return nil
+	}
+	if err := m.store.RecordPortLabel(record); err != nil {
+		return fmt.Errorf("SELinux port labeled but not recorded: %w", err)
+	}
+	return nil
+}
+
+// semanagePort adds (-a) or modifies (-m) a port label
+func (m *Manager) semanagePort(ctx context.Context, action string, port int, proto models.Protocol, selinuxType string) error {
+	cmd := exec.CommandContext(ctx, "semanage", "port", action, "-t", selinuxType, "-p", string(proto), fmt.Sprintf("%d", port))
+	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	if err != nil {
+		return fmt.Errorf("failed to label SELinux port %d/%s as %s: %w (output: %s)", port, proto, selinuxType, err, string(output))
+	}
+	return nil
+}
+
+// findLabel returns the local label of a port
+func findLabel(labels []PortLabel, port int, proto models.Protocol) *PortLabel {
+	for i := range labels {
+		if labels[i].Port == port && strings.EqualFold(string(labels[i].Proto), string(proto)) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// SELinuxConfigPath holds the mode SELinux starts in at boot
+const SELinuxConfigPath = "/etc/selinux/config"
+
+// AutorelabelPath makes the next boot relabel the filesystem
+const AutorelabelPath = "/.autorelabel"
+
+// SELinux modes
+const (
+	SELinuxEnforcing  = "enforcing"
+	SELinuxPermissive = "permissive"
+	SELinuxDisabled   = "disabled"
+)
+
+// GetSELinuxRuntimeMode returns the running mode as reported by getenforce
+func (m *Manager) GetSELinuxRuntimeMode(ctx context.Context) (string, error) {
+	if !m.osInfo.IsRHEL() {
+		return "N/A", nil
+	}
+	output, err := exec.CommandContext(ctx, "getenforce").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get SELinux mode: %w", err)
+	}
+	return strings.ToLower(strings.TrimSpace(string(output))), nil
+}
+
+// GetSELinuxConfigMode returns the mode SELinux is configured to start in
+func (m *Manager) GetSELinuxConfigMode() (string, error) {
+	data, err := os.ReadFile(SELinuxConfigPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", SELinuxConfigPath, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "SELINUX="); ok {
+			return strings.ToLower(strings.TrimSpace(value)), nil
+		}
+	}
+	return "", fmt.Errorf("no SELINUX= line in %s", SELinuxConfigPath)
+}
+
+// SetSELinuxMode switches the running mode and, when persist is set, the
+// mode SELinux starts in. Switching to or from disabled cannot happen at
+// runtime; the config is written and rebootRequired is returned instead.
+// Leaving disabled also schedules a relabel, as files created meanwhile
+// carry no labels.
+func (m *Manager) SetSELinuxMode(ctx context.Context, mode string, persist bool) (rebootRequired bool, err error) {
+	if !m.osInfo.IsRHEL() {
+		return false, nil
+	}
+	switch mode {
+	case SELinuxEnforcing, SELinuxPermissive, SELinuxDisabled:
+	default:
+		return false, fmt.Errorf("invalid SELinux mode %q", mode)
+	}
+
+	current, err := m.GetSELinuxRuntimeMode(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current == SELinuxDisabled || mode == SELinuxDisabled {
+		if !persist {
+			return false, fmt.Errorf("SELinux cannot be switched to %s from %s without a reboot; persist the change instead", mode, current)
+		}
+		rebootRequired = current != mode
+	} else {
+		value := "0"
+		if mode == SELinuxEnforcing {
+			value = "1"
+		}
+		cmd := exec.CommandContext(ctx, "setenforce", value)
+		output, err := cmd.CombinedOutput()
+		platform.RecordCommand(ctx, cmd, output, err)
+		if err != nil {
+			return false, fmt.Errorf("failed to set SELinux %s: %w (output: %s)", mode, err, string(output))
+		}
+	}
+
+	if persist {
+		err := writeSELinuxConfigMode(mode)
+		platform.RecordFileWrite(ctx, SELinuxConfigPath, "SELINUX="+mode, err)
+		if err != nil {
+			return false, err
+		}
+	}
+	if persist && current == SELinuxDisabled && mode != SELinuxDisabled {
+		err := os.WriteFile(AutorelabelPath, nil, 0644)
+		platform.RecordFileWrite(ctx, AutorelabelPath, "", err)
+		if err != nil {
+			return false, fmt.Errorf("failed to schedule relabel: %w", err)
+		}
+	}
+	return rebootRequired, nil
+}
+
+// writeSELinuxConfigMode replaces the SELINUX= line of the config file,
+// keeping everything else
+func writeSELinuxConfigMode(mode string) error {
+	data, err := os.ReadFile(SELinuxConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", SELinuxConfigPath, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	found := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "SELINUX=") {
+			lines[i] = "SELINUX=" + mode
+			found = true
+		}
+	}
+	if !found {
+		lines = append([]string{"SELINUX=" + mode}, lines...)
+	}
+
+	info, err := os.Stat(SELinuxConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", SELinuxConfigPath, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(SELinuxConfigPath), ".config-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", SELinuxConfigPath, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(strings.Join(lines, "\n")); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", SELinuxConfigPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", SELinuxConfigPath, err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", SELinuxConfigPath, err)
+	}
+	if err := os.Rename(tmp.Name(), SELinuxConfigPath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", SELinuxConfigPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ListLocalSELinuxPorts returns the port labels added on this host, as
+// opposed to those shipped with the policy. Port ranges are skipped since
+// Portly only labels single ports.
+func (m *Manager) ListLocalSELinuxPorts(ctx context.Context) ([]PortLabel, error) {
+	if !m.osInfo.IsRHEL() {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "semanage", "port", "-l", "-C")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SELinux ports: %w", err)
+	}
+	return parseSELinuxPorts(string(output)), nil
+}
+
+// parseSELinuxPorts reads `semanage port -l` output, whose lines look like
+// "http_port_t    tcp    8080, 8443"
+func parseSELinuxPorts(output string) []PortLabel {
+	var labels []PortLabel
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasSuffix(fields[0], "_t") {
+			continue
+		}
+		proto := models.Protocol(fields[1])
+		for _, port := range strings.Split(strings.Join(fields[2:], ""), ",") {
+			n, err := strconv.Atoi(port)
+			if err != nil {
+				continue
+			}
+			labels = append(labels, PortLabel{Type: fields[0], Proto: proto, Port: n})
+		}
+	}
+	return labels
+}
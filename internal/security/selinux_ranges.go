@@ -0,0 +1,70 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// ListSELinuxPortRanges returns every port type assignment, from the policy
+// and from local changes
+func (m *Manager) ListSELinuxPortRanges(ctx context.Context) ([]PortRange, error) {
+	if !m.osInfo.IsRHEL() {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "semanage", "port", "-l")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SELinux ports: %w", err)
+	}
+	return parseSELinuxPortRanges(string(output)), nil
+}
+
+// parseSELinuxPortRanges reads `semanage port -l` output including ranges
+// such as "http_port_t    tcp    8008, 8009, 9000-9010"
+func parseSELinuxPortRanges(output string) []PortRange {
+	var ranges []PortRange
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.HasSuffix(fields[0], "_t") {
+			continue
+		}
+		proto := models.Protocol(fields[1])
+		for _, item := range strings.Split(strings.Join(fields[2:], ""), ",") {
+			lowStr, highStr, isRange := strings.Cut(item, "-")
+			low, err := strconv.Atoi(lowStr)
+			if err != nil {
+				continue
+			}
+			high := low
+			if isRange {
+				if high, err = strconv.Atoi(highStr); err != nil {
+					continue
+				}
+			}
+			ranges = append(ranges, PortRange{Type: fields[0], Proto: proto, Low: low, High: high})
+		}
+	}
+	return ranges
+}
+
+// SELinuxPortType returns the type a port is labeled with. As in the
+// kernel, the narrowest matching range wins, so a single-port label beats
+// the ephemeral port ranges.
+func SELinuxPortType(ranges []PortRange, port int, proto models.Protocol) string {
+	label, width := "", -1
+	for _, r := range ranges {
+		if !strings.EqualFold(string(r.Proto), string(proto)) || port < r.Low || port > r.High {
+			continue
+		}
+		if width == -1 || r.High-r.Low < width {
+			label, width = r.Type, r.High-r.Low
+		}
+	}
+	return label
+}
@@ -0,0 +1,194 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+)
+
+// EnsureProduct applies what a product needs for rules on the given
+// ports: its SELinux booleans, port labels of its types, and its AppArmor
+// profile. Changes are recorded in the store; booleans that were already
+// on are not claimed. Products not in the catalog need nothing.
+func (m *Manager) EnsureProduct(ctx context.Context, name string, ports []catalog.Port) error {
+	product, ok := catalog.Default().Get(name)
+	if !ok || m.store == nil {
+		return nil
+	}
+	policy, _ := m.store.GetPolicy(product.Name)
+	policy.Product = product.Name
+	var errs []error
+
+	if m.osInfo.IsRHEL() {
+		for _, boolean := range product.Security.SELinuxBooleans {
+			on, err := m.GetSELinuxBoolean(ctx, boolean)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if on {
+				continue
+			}
+			if err := m.SetSELinuxBoolean(ctx, boolean, true); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			policy.Booleans = append(policy.Booleans, boolean)
+		}
+		for _, port := range ports {
+			selinuxType := ProductPortType(product, port)
+			if selinuxType == "" {
+				continue
+			}
+			if err := m.LabelPort(ctx, product.Name, port.Port, port.Protocol, selinuxType); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if m.osInfo.IsDebian() && product.Security.AppArmor && policy.AppArmorProfile == "" {
+		profile, err := m.GenerateAppArmorProfile(product.Name, product.DefaultPorts())
+		if err == nil {
+			err = m.LoadAppArmorProfile(ctx, product.Name, profile)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			policy.AppArmorProfile = product.Name
+		}
+	}
+
+	// Products with booleans are recorded even without claiming any, so
+	// that releasing another product keeps the booleans they need
+	if len(product.Security.SELinuxBooleans) > 0 || policy.AppArmorProfile != "" {
+		if err := m.store.RecordPolicy(policy); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReleaseProduct undoes what EnsureProduct did: labels on ports no rule
+// uses any more are removed, and once the product has no rules left
+// (last) its booleans and AppArmor profile are reverted. Booleans another
+// product still needs stay on and are handed over to it.
+func (m *Manager) ReleaseProduct(ctx context.Context, name string, ports []catalog.Port, last bool) error {
+	if m.store == nil {
+		return nil
+	}
+	var errs []error
+	for _, port := range ports {
+		if err := m.RemovePortLabel(ctx, port.Port, port.Protocol); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !last {
+		return errors.Join(errs...)
+	}
+
+	policy, ok := m.store.GetPolicy(name)
+	if !ok {
+		return errors.Join(errs...)
+	}
+	for _, boolean := range policy.Booleans {
+		if needed, err := m.handOver(name, boolean); needed || err != nil {
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := m.SetSELinuxBoolean(ctx, boolean, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if policy.AppArmorProfile != "" {
+		if err := m.UnloadAppArmorProfile(ctx, policy.AppArmorProfile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := m.store.ForgetPolicy(name); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// GetSELinuxBoolean reports whether an SELinux boolean is on
+func (m *Manager) GetSELinuxBoolean(ctx context.Context, name string) (bool, error) {
+	if !m.osInfo.IsRHEL() {
+		return false, nil
+	}
+	cmd := exec.CommandContext(ctx, "getsebool", name)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("failed to read SELinux boolean %s: %w (output: %s)", name, err, string(output))
+	}
+	return strings.HasSuffix(strings.TrimSpace(string(output)), "--> on"), nil
+}
+
+// handOver reports whether another product with recorded changes needs a
+// boolean, through its catalog entry or a fix, and moves the claim on the
+// boolean to that product so it is turned off with its last rule instead
+func (m *Manager) handOver(name, boolean string) (bool, error) {
+	for _, policy := range m.store.ListPolicies() {
+		if policy.Product == name {
+			continue
+		}
+		other, ok := catalog.Default().Get(policy.Product)
+		inCatalog := ok && slices.Contains(other.Security.SELinuxBooleans, boolean)
+		if !inCatalog && !slices.Contains(policy.Booleans, boolean) && !slices.Contains(policy.Needs, boolean) {
+			continue
+		}
+		if !slices.Contains(policy.Booleans, boolean) {
+			policy.Booleans = append(policy.Booleans, boolean)
+		}
+		policy.Needs = slices.DeleteFunc(policy.Needs, func(b string) bool { return b == boolean })
+		return true, m.store.RecordPolicy(policy)
+	}
+	return false, nil
+}
+
+// ProductPortType returns the type a product needs on one of its ports:
+// the catalog entry's type, or the product's default port type
+func ProductPortType(product catalog.Product, port catalog.Port) string {
+	for _, p := range product.Ports {
+		if p.Port == port.Port && strings.EqualFold(string(p.Protocol), string(port.Protocol)) {
+			return product.SELinuxType(p)
+		}
+	}
+	return product.Security.SELinuxPortType
+}
+
+// EnableProductBoolean turns on an SELinux boolean for a product and
+// records it, so it is turned off again with the product's last rule. A
+// boolean that is already on is recorded as needed, so that releasing the
+// product that turned it on leaves it on.
+func (m *Manager) EnableProductBoolean(ctx context.Context, name, boolean string) error {
+	on, err := m.GetSELinuxBoolean(ctx, boolean)
+	if err != nil {
+		return err
+	}
+	if !on {
+		if err := m.SetSELinuxBoolean(ctx, boolean, true); err != nil {
+			return err
+		}
+	}
+	if m.store == nil || name == "" {
+		return nil
+	}
+	policy, _ := m.store.GetPolicy(name)
+	policy.Product = name
+	switch {
+	case !on:
+		policy.Booleans = append(policy.Booleans, boolean)
+	case slices.Contains(policy.Booleans, boolean) || slices.Contains(policy.Needs, boolean):
+		return nil
+	default:
+		policy.Needs = append(policy.Needs, boolean)
+	}
+	return m.store.RecordPolicy(policy)
+}
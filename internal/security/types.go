@@ -1,12 +1,28 @@
 package security
 
 import (
+	"time"
+
 	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
 // Manager handles security policy enforcement
 type Manager struct {
 	osInfo *platform.OSInfo
+	store  Store
+}
+
+// Store records the SELinux port labels and other security changes Portly
+// owns; state.Manager implements it
+type Store interface {
+	RecordPortLabel(record models.PortLabelRecord) error
+	ForgetPortLabel(port int, proto models.Protocol) error
+	GetPortLabel(port int, proto models.Protocol) *models.PortLabelRecord
+	RecordPolicy(policy models.AppliedPolicy) error
+	ForgetPolicy(product string) error
+	GetPolicy(product string) (models.AppliedPolicy, bool)
+	ListPolicies() []models.AppliedPolicy
 }
 
 // AppArmorProfileTemplate is the template for generating AppArmor profiles
@@ -16,3 +32,46 @@ type AppArmorProfileTemplate struct {
 	AllowPorts  []int
 	NetworkBind bool
 }
+
+// PortLabel is an SELinux port type assignment
+type PortLabel struct {
+	Type  string          `json:"type"`
+	Proto models.Protocol `json:"proto"`
+	Port  int             `json:"port"`
+}
+
+// PortRange is an SELinux port type assignment covering a range of ports,
+// as listed by the loaded policy
+type PortRange struct {
+	Type  string
+	Proto models.Protocol
+	Low   int
+	High  int
+}
+
+// AVCDenial is an SELinux denial of a network permission on a port.
+// Identical denials are merged and counted.
+type AVCDenial struct {
+	// Permission is name_bind or name_connect
+	Permission string
+	Command    string
+	Port       int
+	Proto      models.Protocol
+	// SourceType is the domain of the denied process, e.g. httpd_t
+	SourceType string
+	// TargetType is the port's type, e.g. unreserved_port_t
+	TargetType string
+	Permissive bool
+	Count      int
+	Last       time.Time
+}
+
+// SELinuxBoolean is a policy boolean with its state and description
+type SELinuxBoolean struct {
+	Name        string
+	On          bool
+	Default     bool
+	Description string
+	// Products lists the catalog products recommending the boolean
+	Products []string
+}
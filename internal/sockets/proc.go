@@ -0,0 +1,176 @@
+package sockets
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// procRoot is where the kernel exposes process and socket tables
+const procRoot = "/proc"
+
+// Socket states in /proc/net; unconnected UDP sockets report TCP_CLOSE
+const (
+	stateListen = "0A"
+	stateClose  = "07"
+)
+
+// List returns the listening sockets. Owning processes are only resolved
+// for sockets the caller may inspect, so run as root for a full picture.
+func List() ([]Listener, error) {
+	tables := []struct {
+		file  string
+		proto models.Protocol
+		state string
+	}{
+		{"tcp", models.TCP, stateListen},
+		{"tcp6", models.TCP, stateListen},
+		{"udp", models.UDP, stateClose},
+		{"udp6", models.UDP, stateClose},
+	}
+
+	var listeners []Listener
+	for _, table := range tables {
+		path := filepath.Join(procRoot, "net", table.file)
+		found, err := parseTable(path, table.proto, table.state)
+		if os.IsNotExist(err) {
+			// IPv6 may be disabled; without the IPv4 table there is no /proc
+			if table.file == "tcp" {
+				return nil, fmt.Errorf("socket inventory requires /proc/net (Linux): %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, found...)
+	}
+
+	resolveOwners(listeners)
+	return listeners, nil
+}
+
+// parseTable reads one /proc/net table, keeping sockets in the given state
+func parseTable(path string, proto models.Protocol, state string) ([]Listener, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var listeners []Listener
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != state {
+			continue
+		}
+		ip, port, err := parseAddress(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		// Connected UDP sockets also report TCP_CLOSE but have a peer
+		if proto == models.UDP {
+			if _, remotePort, err := parseAddress(fields[2]); err != nil || remotePort != 0 {
+				continue
+			}
+		}
+		inode, _ := strconv.ParseUint(fields[9], 10, 64)
+		listeners = append(listeners, Listener{Protocol: proto, Address: ip, Port: port, Inode: inode})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return listeners, nil
+}
+
+// parseAddress decodes an "ADDR:PORT" field. Addresses are written as
+// 32-bit words in host byte order, which is little-endian on the
+// platforms Portly supports.
+func parseAddress(field string) (net.IP, int, error) {
+	addrHex, portHex, ok := strings.Cut(field, ":")
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid socket address '%s'", field)
+	}
+	raw, err := hex.DecodeString(addrHex)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, fmt.Errorf("invalid socket address '%s'", field)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid socket port '%s'", field)
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	if v4 := ip.To4(); v4 != nil && len(raw) == net.IPv6len && !ip.IsUnspecified() {
+		// Keep v4-mapped addresses comparable with IPv4 ones
+		ip = v4
+	}
+	return ip, int(port), nil
+}
+
+// resolveOwners fills in the process owning each socket by matching
+// socket inodes against /proc/<pid>/fd
+func resolveOwners(listeners []Listener) {
+	byInode := make(map[uint64][]int)
+	for i, l := range listeners {
+		if l.Inode != 0 {
+			byInode[l.Inode] = append(byInode[l.Inode], i)
+		}
+	}
+	if len(byInode) == 0 {
+		return
+	}
+
+	procs, err := os.ReadDir(procRoot)
+	if err != nil {
+		return
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join(procRoot, proc.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			for _, i := range byInode[inode] {
+				if listeners[i].PID == 0 {
+					listeners[i].PID = pid
+					listeners[i].Process = processName(pid)
+				}
+			}
+		}
+	}
+}
+
+// processName returns the command name of a process
+func processName(pid int) string {
+	data, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return "?"
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,48 @@
+// Package sockets lists the listening TCP and UDP sockets of this host
+// from /proc/net, with the processes that own them
+package sockets
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Listener is a listening socket
+type Listener struct {
+	Protocol models.Protocol
+	Address  net.IP
+	Port     int
+	Inode    uint64
+	PID      int
+	Process  string
+}
+
+// Wildcard reports whether the socket accepts connections on every address
+func (l *Listener) Wildcard() bool {
+	return l.Address.IsUnspecified()
+}
+
+// Loopback reports whether the socket is only reachable from this host
+func (l *Listener) Loopback() bool {
+	return l.Address.IsLoopback()
+}
+
+// Accepts reports whether the socket receives traffic sent to ip
+func (l *Listener) Accepts(ip net.IP) bool {
+	if l.Wildcard() {
+		// A v6 wildcard also accepts v4 unless bindv6only is set
+		return l.Address.To4() == nil || ip.To4() != nil
+	}
+	return l.Address.Equal(ip)
+}
+
+// String returns a human-readable representation of the listener
+func (l *Listener) String() string {
+	owner := "unknown process"
+	if l.PID > 0 {
+		owner = fmt.Sprintf("%s[%d]", l.Process, l.PID)
+	}
+	return fmt.Sprintf("%s %s (%s)", l.Protocol, net.JoinHostPort(l.Address.String(), fmt.Sprint(l.Port)), owner)
+}
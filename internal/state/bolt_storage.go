@@ -0,0 +1,237 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Bucket layout of the state database
+var (
+	metaBucket      = []byte("meta")
+	rulesBucket     = []byte("rules")
+	productsBucket  = []byte("products")
+	byProductBucket = []byte("rules_by_product")
+	byPortBucket    = []byte("rules_by_port")
+	headerKey       = []byte("header")
+)
+
+// BoltStorage keeps state in a single-file embedded database. Every save is
+// one transaction, and rules are indexed by product and port.
+type BoltStorage struct {
+	path string
+}
+
+// NewBoltStorage creates a database-backed storage
+func NewBoltStorage(path string) *BoltStorage {
+	return &BoltStorage{path: path}
+}
+
+// Path returns the location of the database file
+func (bs *BoltStorage) Path() string {
+	return bs.path
+}
+
+// open opens the database only for the duration of one operation, since
+// bolt holds an exclusive lock on the file while it is open
+func (bs *BoltStorage) open(readOnly bool) (*bolt.DB, error) {
+	if readOnly {
+		if _, err := os.Stat(bs.path); err != nil {
+			return nil, err
+		}
+	}
+	db, err := bolt.Open(bs.path, 0600, &bolt.Options{Timeout: lockTimeout, ReadOnly: readOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	return db, nil
+}
+
+// Load reads the state, running it through the same migrations as the
+// JSON backend
+func (bs *BoltStorage) Load() (*models.State, error) {
+	db, err := bs.open(true)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var doc map[string]interface{}
+	err = db.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		if meta == nil || meta.Get(headerKey) == nil {
+			return fmt.Errorf("state database is empty: %w", os.ErrNotExist)
+		}
+		if err := json.Unmarshal(meta.Get(headerKey), &doc); err != nil {
+			return fmt.Errorf("invalid state header: %w", err)
+		}
+
+		var rules []json.RawMessage
+		if b := tx.Bucket(rulesBucket); b != nil {
+			b.ForEach(func(_, v []byte) error {
+				rules = append(rules, append(json.RawMessage(nil), v...))
+				return nil
+			})
+		}
+		products := make(map[string]json.RawMessage)
+		if b := tx.Bucket(productsBucket); b != nil {
+			b.ForEach(func(k, v []byte) error {
+				products[string(k)] = append(json.RawMessage(nil), v...)
+				return nil
+			})
+		}
+		doc["rules"] = rules
+		doc["products"] = products
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	state, _, err := Migrate(data)
+	return state, err
+}
+
+// Save replaces the stored state and its indexes in one transaction
+func (bs *BoltStorage) Save(state *models.State) error {
+	db, err := bs.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	header := *state
+	header.Rules = nil
+	header.Products = nil
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{metaBucket, rulesBucket, productsBucket, byProductBucket, byPortBucket} {
+			if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+		}
+		meta, _ := tx.CreateBucket(metaBucket)
+		rules, _ := tx.CreateBucket(rulesBucket)
+		products, _ := tx.CreateBucket(productsBucket)
+		byProduct, _ := tx.CreateBucket(byProductBucket)
+		byPort, err := tx.CreateBucket(byPortBucket)
+		if err != nil {
+			return err
+		}
+
+		if err := meta.Put(headerKey, headerData); err != nil {
+			return err
+		}
+		for i, rule := range state.Rules {
+			data, err := json.Marshal(rule)
+			if err != nil {
+				return err
+			}
+			// Keys keep the rules in state order
+			key := []byte(fmt.Sprintf("%08d", i))
+			if err := rules.Put(key, data); err != nil {
+				return err
+			}
+			if err := byProduct.Put(indexKey(rule.Product, rule.ID), key); err != nil {
+				return err
+			}
+			for _, port := range rulePorts(rule) {
+				if err := byPort.Put(indexKey(strconv.Itoa(port), rule.ID), key); err != nil {
+					return err
+				}
+			}
+		}
+		for name, info := range state.Products {
+			data, err := json.Marshal(info)
+			if err != nil {
+				return err
+			}
+			if err := products.Put([]byte(name), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RulesByProduct returns the rules of a product using the index
+func (bs *BoltStorage) RulesByProduct(product string) ([]models.AppliedRule, error) {
+	return bs.lookup(byProductBucket, product)
+}
+
+// RulesByPort returns the rules on an external, internal or firewall port
+func (bs *BoltStorage) RulesByPort(port int) ([]models.AppliedRule, error) {
+	return bs.lookup(byPortBucket, strconv.Itoa(port))
+}
+
+func (bs *BoltStorage) lookup(index []byte, value string) ([]models.AppliedRule, error) {
+	db, err := bs.open(true)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var result []models.AppliedRule
+	err = db.View(func(tx *bolt.Tx) error {
+		idx, rules := tx.Bucket(index), tx.Bucket(rulesBucket)
+		if idx == nil || rules == nil {
+			return nil
+		}
+		prefix := []byte(value + "\x00")
+		c := idx.Cursor()
+		for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), string(prefix)); k, v = c.Next() {
+			var rule models.AppliedRule
+			if err := json.Unmarshal(rules.Get(v), &rule); err != nil {
+				return fmt.Errorf("invalid rule record: %w", err)
+			}
+			result = append(result, rule)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ImportFrom copies another storage's state into an empty database
+func (bs *BoltStorage) ImportFrom(source StateStorage) error {
+	if _, err := os.Stat(bs.path); err == nil {
+		return nil
+	}
+	state, err := source.Load()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to import existing state: %w", err)
+	}
+	return bs.Save(state)
+}
+
+func indexKey(value, id string) []byte {
+	return []byte(value + "\x00" + id)
+}
+
+// rulePorts returns the ports a rule is indexed under
+func rulePorts(rule models.AppliedRule) []int {
+	var ports []int
+	for _, p := range []int{rule.ExternalPort, rule.InternalPort, rule.Port} {
+		if p != 0 && (len(ports) == 0 || ports[len(ports)-1] != p) {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
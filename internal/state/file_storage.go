@@ -18,19 +18,44 @@ func NewFileStorage(path string) *FileStorage {
 	return &FileStorage{path: path}
 }
 
-// Load reads state from disk
+// Path returns the location of the state file
+func (fs *FileStorage) Path() string {
+	return fs.path
+}
+
+// Load reads state from disk, migrating older schema versions. The
+// original file of a migrated state is kept as a backup; the upgraded
+// version is written on the next save.
 func (fs *FileStorage) Load() (*models.State, error) {
 	data, err := os.ReadFile(fs.path)
 	if err != nil {
 		return nil, err
 	}
 
-	var state models.State
-	if err := json.Unmarshal(data, &state); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	state, original, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if original != CurrentVersion {
+		if err := fs.backup(data, original); err != nil {
+			return nil, err
+		}
 	}
 
-	return &state, nil
+	return state, nil
+}
+
+// backup keeps a copy of a state file written with an older schema
+func (fs *FileStorage) backup(data []byte, version string) error {
+	path := fmt.Sprintf("%s.v%s.bak", fs.path, version)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to back up state before migration: %w", err)
+	}
+	return nil
 }
 
 // Save writes state to disk atomically
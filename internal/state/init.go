@@ -1,51 +1,128 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/config"
+	"github.com/orchestrator/unified-firewall/internal/integrity"
 	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
-// NewManager creates a new state manager
+// NewManager creates a state manager using the backend selected in the
+// host configuration
 func NewManager() (*Manager, error) {
 	if err := platform.EnsureStateDir(); err != nil {
 		return nil, fmt.Errorf("failed to create state directory: %w", err)
 	}
 
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := OpenStorage(cfg.StateBackend)
+	if err != nil {
+		return nil, err
+	}
+	return NewManagerWithStorage(storage)
+}
+
+// OpenStorage returns the storage for a configured backend. The first
+// time the bolt backend is used, an existing JSON state is imported.
+func OpenStorage(backend string) (StateStorage, error) {
+	switch backend {
+	case config.BackendBolt:
+		storage := NewBoltStorage(platform.GetStateDBPath())
+		if err := storage.ImportFrom(NewFileStorage(platform.GetStateFilePath())); err != nil {
+			return nil, err
+		}
+		return storage, nil
+	case config.BackendJSON, "":
+		return NewFileStorage(platform.GetStateFilePath()), nil
+	}
+	return nil, fmt.Errorf("unknown state backend '%s'", backend)
+}
+
+// NewManagerWithStorage creates a state manager on top of any storage,
+// initializing an empty state if none was saved yet
+func NewManagerWithStorage(storage StateStorage) (*Manager, error) {
 	m := &Manager{
+		storage:   storage,
 		statePath: platform.GetStateFilePath(),
-		state:     nil,
+	}
+	if l, ok := storage.(Locator); ok {
+		m.statePath = l.Path()
+		m.located = true
 	}
 
-	storage := NewFileStorage(m.statePath)
-	state, err := storage.Load()
+	lock, err := acquireLock(m.statePath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			if err := m.Initialize(); err != nil {
-				return nil, err
-			}
-		} else {
+		return nil, err
+	}
+	defer lock.release()
+
+	m.key, m.keyCreated, m.keyErr = integrity.LoadKey()
+	if m.keyCreated {
+		// A new key next to a sealed journal means the old one was removed
+		if m.sealedBefore, err = audit.Default().Sealed(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.load(); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err := m.initialize(); err != nil {
+			return nil, err
+		}
+		if err := m.write(); err != nil {
+			return nil, err
+		}
+	} else if m.keyCreated && !m.sealedBefore && m.state.Integrity == "" {
+		// First run with integrity checks: seal the existing state
+		if err := m.write(); err != nil {
 			return nil, err
 		}
-	} else {
-		m.state = state
 	}
 
 	return m, nil
 }
 
-// Initialize creates a new state file
+// Initialize replaces the state file with an empty state
 func (m *Manager) Initialize() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lock, err := acquireLock(m.statePath)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	var revision int64
+	if onDisk, err := m.storage.Load(); err == nil {
+		revision = onDisk.Revision
+	}
+	if err := m.initialize(); err != nil {
+		return err
+	}
+	m.state.Revision = revision
+	return m.write()
+}
+
+// initialize builds an empty state for the current OS
+func (m *Manager) initialize() error {
 	osInfo, err := platform.DetectOS()
 	if err != nil {
 		return fmt.Errorf("failed to detect OS: %w", err)
 	}
 
 	m.state = &models.State{
-		Version: "1.0.0",
+		Version: CurrentVersion,
 		OS: models.OSInfo{
 			Family:       string(osInfo.Family),
 			Distribution: osInfo.Distribution,
@@ -56,22 +133,62 @@ func (m *Manager) Initialize() error {
 		Products:    make(map[string]models.ProductInfo),
 		LastUpdated: time.Now().UTC().Format(time.RFC3339),
 	}
-
-	return m.Save()
+	return nil
 }
 
-// Save writes state to disk
+// Save writes the cached state to disk. It fails with ErrStateChanged if
+// another process saved a newer revision since the state was loaded.
 func (m *Manager) Save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.state == nil {
 		return fmt.Errorf("state is not initialized")
 	}
 
-	m.state.LastUpdated = time.Now().UTC().Format(time.RFC3339)
-	storage := NewFileStorage(m.statePath)
-	return storage.Save(m.state)
+	lock, err := acquireLock(m.statePath)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	onDisk, err := m.storage.Load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+	if m.tampered != nil {
+		return m.readOnlyError()
+	}
+	if onDisk != nil && onDisk.Revision != m.state.Revision {
+		return fmt.Errorf("%w (loaded revision %d, on disk %d)", ErrStateChanged, m.state.Revision, onDisk.Revision)
+	}
+	return m.write()
 }
 
 // GetState returns the current state
 func (m *Manager) GetState() *models.State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	return m.state
 }
+
+// Replace swaps in a complete state, as when restoring a backup. The
+// on-disk revision keeps counting so other processes notice the change.
+// A replacement is accepted even when the current state failed
+// verification, since restoring a backup is a way to recover from that.
+func (m *Manager) Replace(replacement *models.State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.apply(func(s *models.State) error {
+		revision := s.Revision
+		*s = *replacement
+		s.Version = CurrentVersion
+		s.Revision = revision
+		return nil
+	}, true)
+}
+
+// Path returns the location of the state file
+func (m *Manager) Path() string {
+	return m.statePath
+}
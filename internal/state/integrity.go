@@ -0,0 +1,107 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/integrity"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// sealInput is the serialization covered by the state's HMAC. Empty
+// collections are normalized because storages may round-trip them as nil.
+// A migrated state is checked with the version it was sealed under, since
+// the migrations since 1.1.0 only add optional fields.
+func sealInput(state *models.State) ([]byte, error) {
+	s := *state
+	s.Integrity = ""
+	if s.SourceVersion != "" {
+		s.Version = s.SourceVersion
+	}
+	if s.Rules == nil {
+		s.Rules = []models.AppliedRule{}
+	}
+	if s.Products == nil {
+		s.Products = map[string]models.ProductInfo{}
+	}
+	return json.Marshal(s)
+}
+
+// seal records the audit journal's head in the state and signs it, if the
+// integrity key is available
+func (m *Manager) seal(state *models.State) error {
+	if m.key == nil {
+		return nil
+	}
+	head, entries, err := audit.Default().Head()
+	if err != nil {
+		return fmt.Errorf("failed to seal state: %w", err)
+	}
+	state.JournalHead, state.JournalEntries = head, entries
+	data, err := sealInput(state)
+	if err != nil {
+		return fmt.Errorf("failed to seal state: %w", err)
+	}
+	state.Integrity = m.key.Sign(data)
+	return nil
+}
+
+// verify checks the state's seal. Without a readable key nothing can be
+// checked, except that an exposed key makes the state untrustworthy.
+func (m *Manager) verify(state *models.State) error {
+	if errors.Is(m.keyErr, integrity.ErrKeyExposed) {
+		return m.keyErr
+	}
+	if m.key == nil {
+		return nil
+	}
+	if state.Integrity == "" {
+		if m.keyCreated && !m.sealedBefore {
+			return nil
+		}
+		if m.sealedBefore {
+			return fmt.Errorf("%w: the state is not sealed and the integrity key was recreated after the journal was sealed", ErrTampered)
+		}
+		return fmt.Errorf("%w: the state is not sealed", ErrTampered)
+	}
+	data, err := sealInput(state)
+	if err != nil {
+		return err
+	}
+	if !m.key.Verify(state.Integrity, data) {
+		return fmt.Errorf("%w: seal does not match %s", ErrTampered, m.statePath)
+	}
+	// Entries journaled after the last save are covered by the next one
+	if state.JournalEntries > 0 {
+		if err := audit.Default().CheckHead(state.JournalHead, state.JournalEntries); err != nil {
+			return fmt.Errorf("%w: %w", ErrTampered, err)
+		}
+	}
+	return nil
+}
+
+// readOnlyError explains why a change was refused
+func (m *Manager) readOnlyError() error {
+	return fmt.Errorf("state is read-only: %w", m.tampered)
+}
+
+// Tampered returns why the state failed verification, or nil. While it is
+// non-nil the manager refuses changes.
+func (m *Manager) Tampered() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tampered
+}
+
+// Reseal accepts the state currently on disk as genuine and signs it. It
+// is the explicit way out of read-only mode after reviewing a hand edit.
+func (m *Manager) Reseal() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.keyErr != nil {
+		return m.keyErr
+	}
+	return m.apply(func(*models.State) error { return nil }, true)
+}
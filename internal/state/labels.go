@@ -0,0 +1,97 @@
+package state
+
+import "github.com/orchestrator/unified-firewall/pkg/models"
+
+// RecordPortLabel stores an SELinux port label Portly set, replacing any
+// record for the same port
+func (m *Manager) RecordPortLabel(record models.PortLabelRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		if existing := s.FindPortLabel(record.Port, record.Proto); existing != nil {
+			*existing = record
+			return nil
+		}
+		s.PortLabels = append(s.PortLabels, record)
+		return nil
+	})
+}
+
+// ForgetPortLabel drops the record of a port label
+func (m *Manager) ForgetPortLabel(port int, proto models.Protocol) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		for i, r := range s.PortLabels {
+			if r.Port == port && r.Proto == proto {
+				s.PortLabels = append(s.PortLabels[:i], s.PortLabels[i+1:]...)
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// GetPortLabel returns the record of a port label Portly owns, or nil
+func (m *Manager) GetPortLabel(port int, proto models.Protocol) *models.PortLabelRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	if r := m.state.FindPortLabel(port, proto); r != nil {
+		record := *r
+		return &record
+	}
+	return nil
+}
+
+// ListPortLabels returns the port labels Portly owns
+func (m *Manager) ListPortLabels() []models.PortLabelRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	return append([]models.PortLabelRecord(nil), m.state.PortLabels...)
+}
+
+// RecordPolicy stores the security changes made for a product
+func (m *Manager) RecordPolicy(policy models.AppliedPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		if s.Policies == nil {
+			s.Policies = make(map[string]models.AppliedPolicy)
+		}
+		s.Policies[policy.Product] = policy
+		return nil
+	})
+}
+
+// ForgetPolicy drops the record of a product's security changes
+func (m *Manager) ForgetPolicy(product string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		delete(s.Policies, product)
+		return nil
+	})
+}
+
+// GetPolicy returns the security changes made for a product
+func (m *Manager) GetPolicy(product string) (models.AppliedPolicy, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	policy, ok := m.state.Policies[product]
+	return policy, ok
+}
+
+// ListPolicies returns the security changes made for every product
+func (m *Manager) ListPolicies() []models.AppliedPolicy {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	policies := make([]models.AppliedPolicy, 0, len(m.state.Policies))
+	for _, p := range m.state.Policies {
+		policies = append(policies, p)
+	}
+	return policies
+}
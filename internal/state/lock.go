@@ -0,0 +1,49 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// lockTimeout bounds how long a writer waits for another process
+const lockTimeout = 10 * time.Second
+
+// fileLock is an exclusive advisory lock shared by every Portly process
+type fileLock struct {
+	file *os.File
+}
+
+// acquireLock takes the lock next to the state file, waiting up to
+// lockTimeout for another process to release it
+func acquireLock(statePath string) (*fileLock, error) {
+	f, err := os.OpenFile(statePath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state lock: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return &fileLock{file: f}, nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock state: %w", err)
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, ErrStateLocked
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// release drops the lock
+func (l *fileLock) release() {
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+}
@@ -0,0 +1,146 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// CurrentVersion is the state schema written by this build
+const CurrentVersion = "1.2.0"
+
+// migration upgrades a raw state document from one schema version to the
+// next. Migrations work on the decoded JSON so that they never depend on
+// the current shape of models.State.
+type migration struct {
+	from    string
+	to      string
+	upgrade func(doc map[string]interface{}) error
+}
+
+// migrations is ordered; each step's to is the next step's from
+var migrations = []migration{
+	{from: "1.0.0", to: "1.1.0", upgrade: migrateTypedRules},
+	{from: "1.1.0", to: "1.2.0", upgrade: migrateAdditiveFields},
+}
+
+// migrateTypedRules gives every pre-1.1.0 record, all of which are NAT
+// rules, an explicit type and normalises protocols to lower case
+func migrateTypedRules(doc map[string]interface{}) error {
+	rules, _ := doc["rules"].([]interface{})
+	for i, raw := range rules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("rule %d is not an object", i)
+		}
+		if t, _ := rule["type"].(string); t == "" {
+			rule["type"] = string(models.RuleTypeNAT)
+		}
+		if proto, ok := rule["protocol"].(string); ok {
+			rule["protocol"] = strings.ToLower(proto)
+		}
+	}
+	if rules == nil {
+		doc["rules"] = []interface{}{}
+	}
+	if doc["products"] == nil {
+		doc["products"] = map[string]interface{}{}
+	}
+	return nil
+}
+
+// migrateAdditiveFields covers 1.2.0, which added rule groups, SELinux
+// port labels, applied policies, the revision counter and the journal
+// head. All of them are
+// optional and start out empty, so existing documents need no changes; the
+// version bump is what makes older builds refuse a file that uses them.
+func migrateAdditiveFields(doc map[string]interface{}) error {
+	return nil
+}
+
+// Migrate decodes a state document, upgrading it step by step to
+// CurrentVersion. It returns the version the document was written with.
+func Migrate(data []byte) (*models.State, string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+
+	original, _ := doc["version"].(string)
+	if original == "" {
+		original = "1.0.0"
+	}
+	cmp, err := compareVersions(original, CurrentVersion)
+	if err != nil {
+		return nil, original, err
+	}
+	if cmp > 0 {
+		return nil, original, fmt.Errorf("%w (file %s, supported %s)", ErrStateTooNew, original, CurrentVersion)
+	}
+
+	version := original
+	for _, step := range migrations {
+		if step.from != version {
+			continue
+		}
+		if err := step.upgrade(doc); err != nil {
+			return nil, original, fmt.Errorf("failed to migrate state from %s to %s: %w", step.from, step.to, err)
+		}
+		version = step.to
+		doc["version"] = version
+	}
+	if version != CurrentVersion {
+		return nil, original, fmt.Errorf("no migration path from state version %s to %s", original, CurrentVersion)
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, original, fmt.Errorf("failed to marshal migrated state: %w", err)
+	}
+	var state models.State
+	if err := json.Unmarshal(upgraded, &state); err != nil {
+		return nil, original, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	state.SourceVersion = original
+	return &state, original, nil
+}
+
+// compareVersions compares two dotted version strings numerically
+func compareVersions(a, b string) (int, error) {
+	pa, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseVersion(v string) ([3]int, error) {
+	var parts [3]int
+	fields := strings.Split(v, ".")
+	if len(fields) > 3 {
+		return parts, fmt.Errorf("invalid state version '%s'", v)
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, fmt.Errorf("invalid state version '%s'", v)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
@@ -2,10 +2,13 @@ package state
 
 import "github.com/orchestrator/unified-firewall/pkg/models"
 
-// IsRuleActive checks if a rule with the same parameters is already active
+// IsRuleActive checks if a NAT rule with the same parameters is already active
 func (m *Manager) IsRuleActive(externalPort int, proto models.Protocol) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	for _, r := range m.state.Rules {
-		if r.Status == models.StatusActive &&
+		if r.IsNAT() && r.Status == models.StatusActive &&
 			r.ExternalPort == externalPort &&
 			r.Proto == proto {
 			return true
@@ -14,51 +17,111 @@ func (m *Manager) IsRuleActive(externalPort int, proto models.Protocol) bool {
 	return false
 }
 
-// GetRuleByPort returns a rule by external port and protocol
+// GetRuleByPort returns a NAT rule by external port and protocol
 func (m *Manager) GetRuleByPort(port int, proto models.Protocol) *models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	for _, r := range m.state.Rules {
-		if r.ExternalPort == port && r.Proto == proto {
+		if r.IsNAT() && r.ExternalPort == port && r.Proto == proto {
 			return &r
 		}
 	}
 	return nil
 }
 
+// FindNATRule returns the tracked record for a NAT rule, matching by ID or
+// by content so that rules listed from a provider can be found
+func (m *Manager) FindNATRule(rule models.NATRule) *models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	if r := m.state.FindRule(rule.ID); r != nil && r.IsNAT() {
+		return r
+	}
+	for i, r := range m.state.Rules {
+		if r.IsNAT() && r.Status != models.StatusRemoved && r.Matches(rule) {
+			return &m.state.Rules[i]
+		}
+	}
+	return nil
+}
+
+// FindFirewallRule returns the tracked record for a firewall rule, matching
+// by ID or by content
+func (m *Manager) FindFirewallRule(rule models.FirewallRule) *models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	if r := m.state.FindRule(rule.ID); r != nil && !r.IsNAT() {
+		return r
+	}
+	for i, r := range m.state.Rules {
+		if r.IsNAT() || r.Status == models.StatusRemoved {
+			continue
+		}
+		if fw := r.FirewallRule(); fw.Matches(rule) {
+			return &m.state.Rules[i]
+		}
+	}
+	return nil
+}
+
 // Rollback marks a rule as failed and returns the rule
 func (m *Manager) Rollback(ruleID string, errMsg string) (*models.AppliedRule, error) {
-	for i, r := range m.state.Rules {
-		if r.ID == ruleID {
-			m.state.Rules[i].Status = models.StatusFailed
-			m.state.Rules[i].ErrorMsg = errMsg
-			if err := m.Save(); err != nil {
-				return nil, err
-			}
-			return &m.state.Rules[i], nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found bool
+	err := m.update(func(s *models.State) error {
+		r := s.FindRule(ruleID)
+		if r == nil {
+			return nil
 		}
+		r.Status = models.StatusFailed
+		r.ErrorMsg = errMsg
+		found = true
+		return nil
+	})
+	if err != nil || !found {
+		return nil, err
 	}
-	return nil, nil
+	return m.state.FindRule(ruleID), nil
 }
 
 // Cleanup removes failed rules from state
 func (m *Manager) Cleanup() error {
-	var activeRules []models.AppliedRule
-	for _, r := range m.state.Rules {
-		if r.Status != models.StatusRemoved {
-			activeRules = append(activeRules, r)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		var activeRules []models.AppliedRule
+		for _, r := range s.Rules {
+			if r.Status != models.StatusRemoved {
+				activeRules = append(activeRules, r)
+			}
 		}
-	}
-	m.state.Rules = activeRules
-	return m.Save()
+		s.Rules = activeRules
+		return nil
+	})
 }
 
 // SetProductInfo updates product information
 func (m *Manager) SetProductInfo(info models.ProductInfo) error {
-	m.state.Products[info.Name] = info
-	return m.Save()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		if s.Products == nil {
+			s.Products = make(map[string]models.ProductInfo)
+		}
+		s.Products[info.Name] = info
+		return nil
+	})
 }
 
 // GetProductInfo returns product information
 func (m *Manager) GetProductInfo(name string) (models.ProductInfo, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	info, ok := m.state.Products[name]
 	return info, ok
 }
@@ -2,51 +2,84 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
 // AddRule adds a rule to the state
 func (m *Manager) AddRule(rule models.AppliedRule) error {
-	if m.state.FindRule(rule.ID) != nil {
-		return fmt.Errorf("rule with ID %s already exists", rule.ID)
-	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		if s.FindRule(rule.ID) != nil {
+			return fmt.Errorf("rule with ID %s already exists", rule.ID)
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		if rule.AppliedAt == "" {
+			rule.AppliedAt = now
+		}
+		rule.UpdatedAt = now
 
-	m.state.Rules = append(m.state.Rules, rule)
-	return m.Save()
+		s.Rules = append(s.Rules, rule)
+		return nil
+	})
 }
 
 // UpdateRule updates an existing rule
 func (m *Manager) UpdateRule(rule models.AppliedRule) error {
-	for i, r := range m.state.Rules {
-		if r.ID == rule.ID {
-			m.state.Rules[i] = rule
-			return m.Save()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		existing := s.FindRule(rule.ID)
+		if existing == nil {
+			return fmt.Errorf("rule with ID %s not found", rule.ID)
 		}
-	}
-	return fmt.Errorf("rule with ID %s not found", rule.ID)
+		rule.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+		*existing = rule
+		return nil
+	})
 }
 
 // RemoveRule removes a rule from the state
 func (m *Manager) RemoveRule(ruleID string) error {
-	if !m.state.RemoveRule(ruleID) {
-		return fmt.Errorf("rule with ID %s not found", ruleID)
-	}
-	return m.Save()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.update(func(s *models.State) error {
+		if !s.RemoveRule(ruleID) {
+			return fmt.Errorf("rule with ID %s not found", ruleID)
+		}
+		return nil
+	})
 }
 
 // GetRule returns a rule by ID
 func (m *Manager) GetRule(ruleID string) *models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	return m.state.FindRule(ruleID)
 }
 
 // ListRules returns all rules
 func (m *Manager) ListRules() []models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	return m.state.Rules
 }
 
 // ListRulesByProduct returns rules for a specific product
 func (m *Manager) ListRulesByProduct(product string) []models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if idx, ok := m.storage.(RuleIndex); ok {
+		if rules, err := idx.RulesByProduct(product); err == nil {
+			return rules
+		}
+	}
+	m.refresh()
 	var result []models.AppliedRule
 	for _, r := range m.state.Rules {
 		if r.Product == product {
@@ -56,8 +89,44 @@ func (m *Manager) ListRulesByProduct(product string) []models.AppliedRule {
 	return result
 }
 
+// ListRulesByPort returns rules on an external, internal or firewall port
+func (m *Manager) ListRulesByPort(port int) []models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if idx, ok := m.storage.(RuleIndex); ok {
+		if rules, err := idx.RulesByPort(port); err == nil {
+			return rules
+		}
+	}
+	m.refresh()
+	var result []models.AppliedRule
+	for _, r := range m.state.Rules {
+		if r.ExternalPort == port || r.InternalPort == port || r.Port == port {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// ListRulesByType returns rules of a specific kind
+func (m *Manager) ListRulesByType(ruleType models.FirewallRuleType) []models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
+	var result []models.AppliedRule
+	for _, r := range m.state.Rules {
+		if r.RuleType() == ruleType {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
 // ListActiveRules returns only active rules
 func (m *Manager) ListActiveRules() []models.AppliedRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh()
 	var result []models.AppliedRule
 	for _, r := range m.state.Rules {
 		if r.Status == models.StatusActive {
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// load reads the state and remembers the size and mtime of its file
+func (m *Manager) load() error {
+	modTime, size := m.fileInfo()
+	state, err := m.storage.Load()
+	if err != nil {
+		return err
+	}
+	m.state = state
+	m.tampered = m.verify(state)
+	m.modTime = modTime
+	m.size = size
+	return nil
+}
+
+// fileInfo returns the size and mtime of the storage file, if it has one
+func (m *Manager) fileInfo() (time.Time, int64) {
+	if !m.located {
+		return time.Time{}, 0
+	}
+	info, err := os.Stat(m.statePath)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
+
+// refresh reloads the state if another process changed it. Storages
+// without a file are always reloaded. A failed read keeps the cached state.
+func (m *Manager) refresh() {
+	if m.state == nil {
+		return
+	}
+	if m.located {
+		modTime, size := m.fileInfo()
+		if modTime.Equal(m.modTime) && size == m.size {
+			return
+		}
+	}
+	m.load()
+}
+
+// Reload discards the cached state and reads it from disk
+func (m *Manager) Reload() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load()
+}
+
+// write bumps the revision and persists the state. Callers hold the lock.
+func (m *Manager) write() error {
+	m.state.Revision++
+	m.state.LastUpdated = time.Now().UTC().Format(time.RFC3339)
+	// Saving writes the current schema
+	m.state.SourceVersion = ""
+	if err := m.seal(m.state); err != nil {
+		return err
+	}
+	if err := m.storage.Save(m.state); err != nil {
+		return err
+	}
+	m.tampered = nil
+	m.modTime, m.size = m.fileInfo()
+	return nil
+}
+
+// update applies a change to the latest on-disk state under the lock, so
+// changes made by other processes since the last load are kept rather
+// than overwritten
+func (m *Manager) update(change func(s *models.State) error) error {
+	return m.apply(change, false)
+}
+
+// apply runs a change under the lock. Unless override is set, a state
+// that fails verification is left untouched.
+func (m *Manager) apply(change func(s *models.State) error, override bool) error {
+	lock, err := acquireLock(m.statePath)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	if err := m.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to reload state: %w", err)
+	}
+	if m.state == nil {
+		return fmt.Errorf("state is not initialized")
+	}
+	if m.tampered != nil && !override {
+		return m.readOnlyError()
+	}
+	if err := change(m.state); err != nil {
+		return err
+	}
+	return m.write()
+}
@@ -1,17 +1,65 @@
 package state
 
 import (
+	"sync"
+	"time"
+
+	apperrors "github.com/orchestrator/unified-firewall/internal/errors"
+	"github.com/orchestrator/unified-firewall/internal/integrity"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
+// Re-export errors for callers of the state package
+var (
+	ErrStateLocked  = apperrors.ErrStateLocked
+	ErrStateChanged = apperrors.ErrStateChanged
+	ErrStateTooNew  = apperrors.ErrStateTooNew
+	ErrTampered     = apperrors.ErrStateTampered
+)
+
 // Manager handles state persistence and retrieval
 type Manager struct {
+	// mu serializes use of the manager from several goroutines, such as
+	// the TUI's commands and the container watch; every exported method
+	// holds it
+	mu sync.Mutex
+
+	storage   StateStorage
 	statePath string
+	located   bool
 	state     *models.State
+
+	// Integrity key, whether this process created it, whether the journal
+	// shows an earlier key sealed changes, and why the state is read-only,
+	// if it is
+	key          integrity.Key
+	keyCreated   bool
+	sealedBefore bool
+	keyErr       error
+	tampered     error
+
+	// Size and modification time of the file when it was last read, used
+	// to notice writes by other processes
+	modTime time.Time
+	size    int64
 }
 
-// StateStorage defines the interface for state storage operations
+// StateStorage defines the interface for state storage operations. Load
+// returns an error wrapping os.ErrNotExist when no state was saved yet.
 type StateStorage interface {
 	Load() (*models.State, error)
 	Save(state *models.State) error
 }
+
+// Locator is implemented by storages kept in a single file. The file is
+// used for cross-process locking and to notice writes by other processes.
+type Locator interface {
+	Path() string
+}
+
+// RuleIndex is implemented by storages that can answer rule queries
+// without loading the whole state
+type RuleIndex interface {
+	RulesByProduct(product string) ([]models.AppliedRule, error)
+	RulesByPort(port int) ([]models.AppliedRule, error)
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// trackedRecords copies the state record of each staged operation's rule
+func (t *Transaction) trackedRecords() []*models.AppliedRule {
+	records := make([]*models.AppliedRule, len(t.ops))
+	if t.stateMgr == nil {
+		return records
+	}
+	for i, op := range t.ops {
+		if r := t.tracked(op); r != nil {
+			record := *r
+			records[i] = &record
+		}
+	}
+	return records
+}
+
+// audit journals the commit with the rules before and after and the
+// commands the provider ran
+func (t *Transaction) audit(before []*models.AppliedRule, recorder *platform.CommandRecorder, result *Result, cause error) {
+	if t.journal == nil {
+		return
+	}
+
+	after := t.trackedRecords()
+	for i, r := range before {
+		if r != nil && after[i] == nil && t.stateMgr != nil {
+			// Removed rules are no longer matched by content
+			if current := t.stateMgr.GetRule(r.ID); current != nil {
+				record := *current
+				after[i] = &record
+			}
+		}
+	}
+	entry := audit.Entry{
+		Action:   t.action,
+		Commands: recorder.Records(),
+		Result:   audit.ResultSuccess,
+	}
+	for i := range t.ops {
+		op := t.ops[i]
+		entry.Changes = append(entry.Changes, audit.Change{Operation: &op, Before: before[i], After: after[i]})
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+		entry.Result = audit.ResultFailed
+		if len(result.RolledBack) > 0 {
+			entry.Result = audit.ResultRolledBack
+		}
+	}
+
+	result.AuditErr = t.journal.Append(entry)
+}
+
+// checkIntegrity refuses to change the firewall while the state or the
+// journal fails verification, so tampering cannot be papered over
+func (t *Transaction) checkIntegrity() error {
+	if t.stateMgr != nil {
+		if err := t.stateMgr.Tampered(); err != nil {
+			return fmt.Errorf("refusing to apply changes: %w", err)
+		}
+	}
+	if t.journal != nil {
+		if err := t.journal.Verify(); err != nil {
+			return fmt.Errorf("refusing to apply changes: %w", err)
+		}
+	}
+	return nil
+}
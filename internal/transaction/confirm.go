@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// CommitConfirmed commits the transaction and records it as pending. A
+// helper outside the calling process is armed to revert the change at the
+// deadline unless Confirm is called first, so it is undone even if the
+// caller dies with its SSH session. The commit's result is returned for
+// reporting once the change is confirmed, or the revert's result when the
+// change could not be recorded.
+func (t *Transaction) CommitConfirmed(ctx context.Context, timeout time.Duration) (*Pending, *Result, error) {
+	existing, err := LoadPending()
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		return nil, nil, drivers.ErrPendingChange
+	}
+
+	snapshot := takeSnapshot(ctx, t.provider)
+	reenables := t.reenablesDisabled()
+	result, err := t.Commit(ctx)
+	if err != nil {
+		return nil, result, err
+	}
+
+	now := time.Now().UTC()
+	pending := &Pending{
+		Operations: result.Applied,
+		Snapshot:   snapshot,
+		AppliedAt:  now.Format(time.RFC3339Nano),
+		Deadline:   now.Add(timeout).Format(time.RFC3339),
+		Group:      t.group,
+		Disable:    reenables,
+	}
+	if err := savePending(pending); err != nil {
+		// Without a record nothing could revert the change later
+		revertResult, revertErr := revertOps(ctx, t.provider, t.stateMgr, pending)
+		if revertErr != nil {
+			return nil, revertResult, errors.Join(
+				fmt.Errorf("failed to record pending change: %w", err),
+				fmt.Errorf("failed to revert it, the change is still applied: %w", revertErr))
+		}
+		return nil, revertResult, fmt.Errorf("failed to record pending change, reverted: %w", err)
+	}
+
+	helper, err := armRevertHelper(pending)
+	if err != nil {
+		// Without a helper a dropped session would leave the change applied
+		if revertErr := RevertPending(ctx, t.provider, t.stateMgr); revertErr != nil {
+			err = errors.Join(err, revertErr)
+		}
+		return nil, result, fmt.Errorf("failed to arm automatic revert, reverted: %w", err)
+	}
+	pending.Helper = helper
+	if err := savePending(pending); err != nil {
+		return nil, result, err
+	}
+	return pending, result, nil
+}
+
+// RevertExpired reverts the pending change if its deadline has passed,
+// recovering from a process that died while waiting for confirmation
+func RevertExpired(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager) (bool, error) {
+	pending, err := LoadPending()
+	if err != nil || pending == nil || !pending.Expired() {
+		return false, err
+	}
+	return true, RevertPending(ctx, provider, stateMgr)
+}
+
+// RevertPending undoes the pending change and checks the ruleset against
+// the snapshot taken before it was applied
+func RevertPending(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager) error {
+	pending, err := claimPending()
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return fmt.Errorf("no pending change to revert")
+	}
+
+	result, err := revertOps(ctx, provider, stateMgr, pending)
+	if err != nil {
+		// Put the record back so the revert can be retried
+		if restoreErr := unclaimPending(); restoreErr != nil {
+			err = errors.Join(err, restoreErr)
+		}
+		return fmt.Errorf("failed to revert pending change: %w", err)
+	}
+	if err := releasePending(pending); err != nil {
+		return err
+	}
+	return errors.Join(result.JournalErr(), verifySnapshot(ctx, provider, pending.Snapshot))
+}
+
+// revertOps applies the inverse of the pending operations in reverse
+// order, with the group and disable flags recorded for the revert
+func revertOps(ctx context.Context, provider drivers.Provider, stateMgr *state.Manager, pending *Pending) (*Result, error) {
+	txn := New(provider, stateMgr)
+	txn.SetForce(true)
+	txn.SetAction(audit.ActionRevert)
+	txn.SetGroup(pending.Group)
+	txn.SetDisable(pending.Disable)
+	ops := pending.Operations
+	for i := len(ops) - 1; i >= 0; i-- {
+		if err := txn.Stage(ops[i].Inverse()); err != nil {
+			return nil, err
+		}
+	}
+	return txn.Commit(ctx)
+}
+
+// reenablesDisabled reports whether the transaction re-applies rules that
+// state holds as disabled, as enabling a group does
+func (t *Transaction) reenablesDisabled() bool {
+	if t.stateMgr == nil {
+		return false
+	}
+	for _, op := range t.ops {
+		if op.Kind != models.OpApplyNAT && op.Kind != models.OpOpenPort {
+			continue
+		}
+		if existing := t.tracked(op); existing != nil && existing.Status == models.StatusDisabled {
+			return true
+		}
+	}
+	return false
+}
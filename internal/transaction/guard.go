@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// checkSSHLockout refuses operations that would close the port or drop the
+// source IP the current SSH session depends on
+func checkSSHLockout(ops []models.Operation, conn *platform.SSHConnection) error {
+	if conn == nil {
+		return nil
+	}
+
+	for _, op := range ops {
+		if cutsSSH(op, conn) {
+			return fmt.Errorf("%s (%s:%d): %w; use force to override",
+				op, conn.ClientIP, conn.ServerPort, drivers.ErrSSHLockout)
+		}
+	}
+	return nil
+}
+
+func cutsSSH(op models.Operation, conn *platform.SSHConnection) bool {
+	switch op.Kind {
+	case models.OpClosePort:
+		rule := op.Firewall
+		switch rule.Type {
+		case models.RuleTypeTrustIP:
+			return sourceMatches(rule.SourceIP, conn.ClientIP)
+		case models.RuleTypePortLimit:
+			return rule.Port == conn.ServerPort && rule.Protocol != models.UDP &&
+				sourceMatches(rule.SourceIP, conn.ClientIP)
+		default:
+			return rule.Port == conn.ServerPort && rule.Protocol != models.UDP
+		}
+
+	case models.OpApplyNAT:
+		// Forwarding the SSH port elsewhere would block reconnecting
+		return op.NAT.ExternalPort == conn.ServerPort && op.NAT.Proto != models.UDP
+	}
+	return false
+}
+
+// sourceMatches reports whether a rule's source address or CIDR covers ip
+func sourceMatches(source, ip string) bool {
+	if source == ip {
+		return true
+	}
+	_, network, err := net.ParseCIDR(source)
+	if err != nil {
+		return false
+	}
+	return network.Contains(net.ParseIP(ip))
+}
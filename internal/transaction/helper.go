@@ -0,0 +1,101 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+)
+
+// RevertHelperCommand is the hidden subcommand the revert helper is
+// started with, followed by the AppliedAt of the change it guards. The
+// main package must hand it to RunRevertHelper before anything else:
+//
+//	if len(os.Args) == 3 && os.Args[1] == transaction.RevertHelperCommand {
+//		os.Exit(transaction.RunRevertHelper(os.Args[2]))
+//	}
+const RevertHelperCommand = "__revert-pending"
+
+// armRevertHelper starts a helper that reverts pending at its deadline.
+// A systemd-run timer is used where available since it survives the
+// session; otherwise a detached process in its own session waits for it.
+func armRevertHelper(pending *Pending) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	if systemdRun, err := exec.LookPath("systemd-run"); err == nil {
+		delay := int(math.Ceil(pending.Remaining().Seconds())) + 1
+		unit := fmt.Sprintf("portly-revert-%d", time.Now().UnixNano())
+		cmd := exec.Command(systemdRun,
+			"--unit="+unit,
+			fmt.Sprintf("--on-active=%ds", delay),
+			"--timer-property=AccuracySec=1s",
+			"--collect",
+			exe, RevertHelperCommand, pending.AppliedAt)
+		if err := cmd.Run(); err == nil {
+			return "systemd:" + unit, nil
+		}
+	}
+
+	cmd := exec.Command(exe, RevertHelperCommand, pending.AppliedAt)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start revert helper: %w", err)
+	}
+	pid := cmd.Process.Pid
+	cmd.Process.Release()
+	return "pid:" + strconv.Itoa(pid), nil
+}
+
+// disarmRevertHelper stops the helper of a confirmed or reverted change.
+// A helper left running finds nothing to revert and exits on its own.
+func disarmRevertHelper(helper string) {
+	if unit, ok := strings.CutPrefix(helper, "systemd:"); ok {
+		exec.Command("systemctl", "stop", unit+".timer").Run()
+	}
+}
+
+// RunRevertHelper waits for the deadline of the change applied at
+// appliedAt and reverts it unless it was confirmed or replaced meanwhile.
+// It returns the process exit code.
+func RunRevertHelper(appliedAt string) int {
+	for {
+		pending, err := LoadPending()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if pending == nil || pending.AppliedAt != appliedAt {
+			return 0
+		}
+		if pending.Expired() {
+			break
+		}
+		time.Sleep(min(pending.Remaining(), time.Second))
+	}
+
+	provider, err := drivers.NewProviderFactory().GetProvider()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	stateMgr, err := state.NewManager()
+	if err != nil {
+		stateMgr = nil
+	}
+	if _, err := RevertExpired(context.Background(), provider, stateMgr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,109 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// LoadPending returns the unconfirmed change, or nil if there is none
+func LoadPending() (*Pending, error) {
+	return readPending(platform.GetPendingFilePath())
+}
+
+// readPending reads a pending change record, or returns nil if there is none
+func readPending(path string) (*Pending, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var pending Pending
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending change: %w", err)
+	}
+	return &pending, nil
+}
+
+// Confirm keeps the pending change, cancelling its automatic revert
+func Confirm() error {
+	pending, err := claimPending()
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return fmt.Errorf("no pending change to confirm; it may already have been reverted")
+	}
+	return releasePending(pending)
+}
+
+// Remaining returns the time left before the change is reverted
+func (p *Pending) Remaining() time.Duration {
+	deadline, err := time.Parse(time.RFC3339, p.Deadline)
+	if err != nil {
+		return 0
+	}
+	return time.Until(deadline)
+}
+
+// Expired reports whether the confirmation deadline has passed
+func (p *Pending) Expired() bool {
+	return p.Remaining() <= 0
+}
+
+func savePending(pending *Pending) error {
+	if err := platform.EnsureStateDir(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending change: %w", err)
+	}
+
+	path := platform.GetPendingFilePath()
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending change: %w", err)
+	}
+	return os.Rename(tempPath, path)
+}
+
+// claimPending takes the pending change so that only one of the TUI, the
+// revert helper and a later session confirms or reverts it. It returns
+// nil if there is no change or another process claimed it first.
+func claimPending() (*Pending, error) {
+	path := platform.GetPendingFilePath()
+	if err := os.Rename(path, claimedPath()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim pending change: %w", err)
+	}
+	return readPending(claimedPath())
+}
+
+// unclaimPending puts back a claimed change that could not be handled
+func unclaimPending() error {
+	return os.Rename(claimedPath(), platform.GetPendingFilePath())
+}
+
+// releasePending drops a claimed change and its revert helper
+func releasePending(pending *Pending) error {
+	disarmRevertHelper(pending.Helper)
+	err := os.Remove(claimedPath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func claimedPath() string {
+	return platform.GetPendingFilePath() + ".claimed"
+}
@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// recordSuccess stores created rules as active and marks removed ones
+func (t *Transaction) recordSuccess() error {
+	if t.stateMgr == nil {
+		return nil
+	}
+
+	for _, op := range t.ops {
+		var err error
+		switch op.Kind {
+		case models.OpApplyNAT:
+			err = t.upsert(models.NewAppliedNATRule(*op.NAT, models.StatusActive))
+		case models.OpOpenPort:
+			err = t.upsert(models.NewAppliedFirewallRule(*op.Firewall, models.StatusActive))
+		case models.OpRemoveNAT, models.OpClosePort:
+			err = t.markRemoved(op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordFailure stores rules that could not be created as failed. A rule
+// that is already tracked keeps its record, since the failed attempt left
+// it as it was.
+func (t *Transaction) recordFailure(cause error) {
+	if t.stateMgr == nil {
+		return
+	}
+
+	for _, op := range t.ops {
+		var rule models.AppliedRule
+		switch op.Kind {
+		case models.OpApplyNAT:
+			rule = models.NewAppliedNATRule(*op.NAT, models.StatusFailed)
+		case models.OpOpenPort:
+			rule = models.NewAppliedFirewallRule(*op.Firewall, models.StatusFailed)
+		default:
+			continue
+		}
+		if t.stateMgr.GetRule(rule.ID) != nil {
+			continue
+		}
+		rule.ErrorMsg = cause.Error()
+		t.upsert(rule)
+	}
+}
+
+// markRemoved flags the tracked record for a removed rule, if any
+func (t *Transaction) markRemoved(op models.Operation) error {
+	existing := t.tracked(op)
+	if existing == nil {
+		return nil
+	}
+	rule := *existing
+	rule.Status = models.StatusRemoved
+	if t.disable {
+		rule.Status = models.StatusDisabled
+	}
+	rule.ErrorMsg = ""
+	return t.stateMgr.UpdateRule(rule)
+}
+
+// tracked returns the state record for the rule an operation acts on
+func (t *Transaction) tracked(op models.Operation) *models.AppliedRule {
+	if op.NAT != nil {
+		return t.stateMgr.FindNATRule(*op.NAT)
+	}
+	return t.stateMgr.FindFirewallRule(*op.Firewall)
+}
+
+func (t *Transaction) upsert(rule models.AppliedRule) error {
+	rule.Group = t.group
+	if existing := t.stateMgr.GetRule(rule.ID); existing != nil {
+		rule.AppliedAt = existing.AppliedAt
+		if rule.Group == "" {
+			rule.Group = existing.Group
+		}
+		return t.stateMgr.UpdateRule(rule)
+	}
+	return t.stateMgr.AddRule(rule)
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// defaultSecurity returns a security manager recording into stateMgr, or
+// nil when changes are not tracked
+func defaultSecurity(stateMgr *state.Manager) *security.Manager {
+	if stateMgr == nil {
+		return nil
+	}
+	secMgr, err := security.NewManager()
+	if err != nil {
+		return nil
+	}
+	secMgr.SetStore(stateMgr)
+	return secMgr
+}
+
+// applySecurity applies the security policies of products that gained
+// rules and releases those of products that lost them
+func (t *Transaction) applySecurity(ctx context.Context) error {
+	if t.security == nil || t.stateMgr == nil {
+		return nil
+	}
+
+	added := make(map[string][]catalog.Port)
+	removed := make(map[string][]catalog.Port)
+	for _, op := range t.ops {
+		product, port := t.securityTarget(op)
+		if product == "" {
+			continue
+		}
+		switch op.Kind {
+		case models.OpApplyNAT, models.OpOpenPort:
+			added[product] = appendPort(added[product], port)
+		case models.OpRemoveNAT, models.OpClosePort:
+			removed[product] = appendPort(removed[product], port)
+		}
+	}
+
+	var errs []error
+	active := t.stateMgr.ListActiveRules()
+	for _, product := range sortedKeys(removed) {
+		var free []catalog.Port
+		for _, port := range removed[product] {
+			if !portInUse(active, port) {
+				free = append(free, port)
+			}
+		}
+		if err := t.security.ReleaseProduct(ctx, product, free, !productInUse(active, product)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, product := range sortedKeys(added) {
+		if err := t.security.EnsureProduct(ctx, product, added[product]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// securityTarget returns the product of an operation's rule and the local
+// port its service listens on. NAT rules to other hosts have no local
+// port; trusted IPs have none either.
+func (t *Transaction) securityTarget(op models.Operation) (string, catalog.Port) {
+	var product string
+	var port catalog.Port
+	switch {
+	case op.NAT != nil:
+		product = op.NAT.Product
+		if isLocalAddress(op.NAT.InternalIP) {
+			port = catalog.Port{Port: op.NAT.InternalPort, Protocol: op.NAT.Proto}
+		}
+	case op.Firewall != nil:
+		product = op.Firewall.Product
+		if op.Firewall.Type != models.RuleTypeTrustIP {
+			port = catalog.Port{Port: op.Firewall.Port, Protocol: op.Firewall.Protocol}
+		}
+	}
+	// Rules listed from a backend carry no product; state knows it
+	if product == "" {
+		if record := t.tracked(op); record != nil {
+			product = record.Product
+		}
+	}
+	return product, port
+}
+
+// appendPort adds a port once, skipping the zero port
+func appendPort(ports []catalog.Port, port catalog.Port) []catalog.Port {
+	if port.Port == 0 {
+		return ports
+	}
+	for _, p := range ports {
+		if p.Port == port.Port && p.Protocol == port.Protocol {
+			return ports
+		}
+	}
+	return append(ports, port)
+}
+
+// portInUse reports whether an active rule still serves a local port
+func portInUse(active []models.AppliedRule, port catalog.Port) bool {
+	for _, r := range active {
+		if !strings.EqualFold(string(r.Proto), string(port.Protocol)) {
+			continue
+		}
+		if r.IsNAT() {
+			if r.InternalPort == port.Port && isLocalAddress(r.InternalIP) {
+				return true
+			}
+		} else if r.Port == port.Port {
+			return true
+		}
+	}
+	return false
+}
+
+// productInUse reports whether a product still has active rules
+func productInUse(active []models.AppliedRule, product string) bool {
+	for _, r := range active {
+		if r.Product == product {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string][]catalog.Port) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isLocalAddress reports whether ip belongs to this host
+func isLocalAddress(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	if parsed.IsLoopback() {
+		return true
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.Equal(parsed) {
+			return true
+		}
+	}
+	return false
+}
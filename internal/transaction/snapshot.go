@@ -0,0 +1,75 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// takeSnapshot captures the provider's current ruleset
+func takeSnapshot(ctx context.Context, provider drivers.Provider) Snapshot {
+	var snapshot Snapshot
+	if provider == nil {
+		return snapshot
+	}
+	if nat, err := provider.ListNATRules(ctx); err == nil {
+		snapshot.NATRules = nat
+	}
+	if fw, err := provider.ListFirewallRules(ctx); err == nil {
+		snapshot.FirewallRules = fw
+	}
+	return snapshot
+}
+
+// verifySnapshot reports rules that differ between the live ruleset and a
+// snapshot, ignoring backend-specific IDs
+func verifySnapshot(ctx context.Context, provider drivers.Provider, snapshot Snapshot) error {
+	current := takeSnapshot(ctx, provider)
+
+	missing, extra := 0, 0
+	for _, want := range snapshot.NATRules {
+		if !containsNAT(current, want) {
+			missing++
+		}
+	}
+	for _, have := range current.NATRules {
+		if !containsNAT(snapshot, have) {
+			extra++
+		}
+	}
+	for _, want := range snapshot.FirewallRules {
+		if !containsFirewall(current, want) {
+			missing++
+		}
+	}
+	for _, have := range current.FirewallRules {
+		if !containsFirewall(snapshot, have) {
+			extra++
+		}
+	}
+
+	if missing > 0 || extra > 0 {
+		return fmt.Errorf("ruleset differs from snapshot after revert: %d missing, %d extra", missing, extra)
+	}
+	return nil
+}
+
+func containsNAT(s Snapshot, rule models.NATRule) bool {
+	for _, r := range s.NATRules {
+		if r.Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFirewall(s Snapshot, rule models.FirewallRule) bool {
+	for _, r := range s.FirewallRules {
+		if r.Matches(rule) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// New creates an empty transaction. stateMgr may be nil, in which case the
+// outcome is not recorded.
+func New(provider drivers.Provider, stateMgr *state.Manager) *Transaction {
+	return &Transaction{
+		provider: provider,
+		stateMgr: stateMgr,
+		journal:  audit.Default(),
+		security: defaultSecurity(stateMgr),
+		action:   audit.ActionApply,
+	}
+}
+
+// Stage adds an operation to the transaction
+func (t *Transaction) Stage(op models.Operation) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// ApplyNAT stages a NAT rule for creation
+func (t *Transaction) ApplyNAT(rule models.NATRule) error {
+	return t.Stage(models.Operation{Kind: models.OpApplyNAT, NAT: &rule})
+}
+
+// RemoveNAT stages a NAT rule for removal
+func (t *Transaction) RemoveNAT(rule models.NATRule) error {
+	return t.Stage(models.Operation{Kind: models.OpRemoveNAT, NAT: &rule})
+}
+
+// OpenPort stages a port, IP-limited port or trusted IP rule for creation
+func (t *Transaction) OpenPort(rule models.FirewallRule) error {
+	return t.Stage(models.Operation{Kind: models.OpOpenPort, Firewall: &rule})
+}
+
+// ClosePort stages a firewall rule for removal
+func (t *Transaction) ClosePort(rule models.FirewallRule) error {
+	return t.Stage(models.Operation{Kind: models.OpClosePort, Firewall: &rule})
+}
+
+// SetForce allows operations that would cut off the current SSH session
+func (t *Transaction) SetForce(force bool) {
+	t.force = force
+}
+
+// SetGroup tags the rules the transaction creates with a group, so they
+// can be listed, disabled and removed together
+func (t *Transaction) SetGroup(group string) {
+	t.group = group
+}
+
+// SetDisable records removed rules as disabled instead of removed, so
+// they can be re-applied later
+func (t *Transaction) SetDisable(disable bool) {
+	t.disable = disable
+}
+
+// SetJournal replaces the audit journal; nil disables journaling
+func (t *Transaction) SetJournal(journal *audit.Journal) {
+	t.journal = journal
+}
+
+// SetAction labels the commit in the audit journal
+func (t *Transaction) SetAction(action audit.Action) {
+	t.action = action
+}
+
+// SetSecurity replaces the manager applying product security policies;
+// nil leaves SELinux and AppArmor untouched
+func (t *Transaction) SetSecurity(secMgr *security.Manager) {
+	t.security = secMgr
+}
+
+// Operations returns the staged operations
+func (t *Transaction) Operations() []models.Operation {
+	return t.ops
+}
+
+// Commit applies all staged operations. Providers implementing
+// drivers.BatchApplier apply them atomically; otherwise they are applied
+// one at a time and completed steps are reversed if a later one fails.
+func (t *Transaction) Commit(ctx context.Context) (*Result, error) {
+	result := &Result{}
+	if len(t.ops) == 0 {
+		return result, nil
+	}
+	if t.provider == nil {
+		return result, drivers.ErrNoProviderAvailable
+	}
+	if err := t.checkIntegrity(); err != nil {
+		return result, err
+	}
+	if !t.force {
+		if err := checkSSHLockout(t.ops, platform.CurrentSSHConnection()); err != nil {
+			return result, err
+		}
+	}
+
+	ctx, recorder := platform.WithCommandRecorder(ctx)
+	before := t.trackedRecords()
+
+	var err error
+	if batcher, ok := t.provider.(drivers.BatchApplier); ok {
+		result.Batched = true
+		err = batcher.ApplyBatch(ctx, t.ops)
+		if err == nil {
+			result.Applied = t.ops
+		}
+	} else {
+		err = t.applySequential(ctx, result)
+	}
+
+	if err != nil {
+		t.recordFailure(err)
+		t.audit(before, recorder, result, err)
+		return result, err
+	}
+
+	if err := t.recordSuccess(); err != nil {
+		err = fmt.Errorf("rules applied but state update failed: %w", err)
+		t.audit(before, recorder, result, err)
+		return result, err
+	}
+	result.SecurityErr = t.applySecurity(ctx)
+	t.audit(before, recorder, result, nil)
+	return result, nil
+}
+
+// applySequential applies operations in order, reversing completed ones
+// when a step fails
+func (t *Transaction) applySequential(ctx context.Context, result *Result) error {
+	for _, op := range t.ops {
+		if err := drivers.Execute(ctx, t.provider, op); err != nil {
+			t.rollback(ctx, result)
+			if len(result.RollbackErrors) > 0 {
+				return fmt.Errorf("%s failed: %w (rollback incomplete: %v)", op, err, result.RollbackErrors)
+			}
+			return fmt.Errorf("%s failed: %w", op, err)
+		}
+		result.Applied = append(result.Applied, op)
+	}
+	return nil
+}
+
+// rollback reverses applied operations in reverse order
+func (t *Transaction) rollback(ctx context.Context, result *Result) {
+	for i := len(result.Applied) - 1; i >= 0; i-- {
+		inverse := result.Applied[i].Inverse()
+		if err := drivers.Execute(ctx, t.provider, inverse); err != nil {
+			result.RollbackErrors = append(result.RollbackErrors, fmt.Errorf("%s: %w", inverse, err))
+			continue
+		}
+		result.RolledBack = append(result.RolledBack, result.Applied[i])
+	}
+	result.Applied = nil
+}
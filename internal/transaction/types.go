@@ -0,0 +1,69 @@
+// Package transaction applies groups of firewall changes as a single unit
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Transaction stages provider operations and applies them all-or-nothing
+type Transaction struct {
+	provider drivers.Provider
+	stateMgr *state.Manager
+	journal  *audit.Journal
+	security *security.Manager
+	action   audit.Action
+	ops      []models.Operation
+	force    bool
+	group    string
+	disable  bool
+}
+
+// Result describes the outcome of a commit
+type Result struct {
+	Applied        []models.Operation
+	RolledBack     []models.Operation
+	RollbackErrors []error
+	Batched        bool
+	// AuditErr is set when the change could not be written to the journal
+	AuditErr error
+	// SecurityErr is set when product security policies could not be
+	// applied or released; the rules themselves stay in place
+	SecurityErr error
+}
+
+// JournalErr reports a change that was applied but could not be journaled
+func (r *Result) JournalErr() error {
+	if r == nil || r.AuditErr == nil {
+		return nil
+	}
+	return fmt.Errorf("change applied but not journaled: %w", r.AuditErr)
+}
+
+// Pending is a committed change awaiting operator confirmation. It is
+// persisted so that a later process can confirm or revert it.
+type Pending struct {
+	Operations []models.Operation `json:"operations"`
+	Snapshot   Snapshot           `json:"snapshot"`
+	AppliedAt  string             `json:"applied_at"`
+	Deadline   string             `json:"deadline"`
+	// Helper identifies the process or systemd unit that reverts the
+	// change at the deadline
+	Helper string `json:"helper,omitempty"`
+	// Group and Disable are the flags of the revert transaction: the
+	// change's group, and whether the rules it re-enabled go back to
+	// disabled rather than removed
+	Group   string `json:"group,omitempty"`
+	Disable bool   `json:"disable,omitempty"`
+}
+
+// Snapshot is the provider's ruleset captured before a change
+type Snapshot struct {
+	NATRules      []models.NATRule      `json:"nat_rules"`
+	FirewallRules []models.FirewallRule `json:"firewall_rules"`
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/drift"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// AdoptForm selects unmanaged rules and the product/description to record
+// them under, or with remove set, the rules to delete from the firewall
+type AdoptForm struct {
+	remove      bool
+	items       []drift.Item
+	selected    []bool
+	cursor      int
+	focus       int // 0: rule list, 1: product, 2: description
+	product     textinput.Model
+	description textinput.Model
+}
+
+// NewAdoptForm creates an adopt form for the given unmanaged rules
+func NewAdoptForm(items []drift.Item) *AdoptForm {
+	product := textinput.New()
+	product.Placeholder = "system"
+	product.Prompt = "Product: "
+	description := textinput.New()
+	description.Placeholder = "Optional description"
+	description.Prompt = "Description: "
+
+	return &AdoptForm{
+		items:       items,
+		selected:    make([]bool, len(items)),
+		product:     product,
+		description: description,
+	}
+}
+
+// Selection returns the rules marked for adoption
+func (f *AdoptForm) Selection() []drift.Item {
+	var items []drift.Item
+	for i, item := range f.items {
+		if f.selected[i] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (f *AdoptForm) setFocus(focus int) {
+	f.focus = focus % 3
+	f.product.Blur()
+	f.description.Blur()
+	switch f.focus {
+	case 1:
+		f.product.Focus()
+	case 2:
+		f.description.Focus()
+	}
+}
+
+// openAdopt shows the unmanaged rules of the current drift report for
+// adoption, or with remove set, for removal
+func (m *Model) openAdopt(remove bool) (tea.Model, tea.Cmd) {
+	if m.driftReport == nil {
+		return m, nil
+	}
+	items := m.driftReport.Unmanaged()
+	if len(items) == 0 {
+		return m, nil
+	}
+	m.adoptForm = NewAdoptForm(items)
+	m.adoptForm.remove = remove
+	m.screen = ScreenAdopt
+	return m, nil
+}
+
+// adoptSelected records the selected rules in state
+func (m *Model) adoptSelected() (tea.Model, tea.Cmd) {
+	form := m.adoptForm
+	items := form.Selection()
+	if len(items) == 0 {
+		return m, nil
+	}
+	product := form.product.Value()
+	description := form.description.Value()
+	m.loadingMsg = "Adopting selected rules..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		adopted, err := drift.AdoptAll(m.stateMgr, items, product, description)
+		if err != nil {
+			return errMsg{err}
+		}
+		return successMsg{fmt.Sprintf("Adopted %d rule(s) into Portly management", len(adopted))}
+	}
+}
+
+// removeSelected deletes the selected rules from the firewall
+func (m *Model) removeSelected() (tea.Model, tea.Cmd) {
+	items := m.adoptForm.Selection()
+	if len(items) == 0 {
+		return m, nil
+	}
+	txn, err := drift.Removal(m.provider, m.stateMgr, items)
+	if err != nil {
+		m.lastError = err
+		m.screen = ScreenError
+		return m, nil
+	}
+	return m.commitChange(txn, "Removing selected rules...", fmt.Sprintf("Removed %d unmanaged rule(s)", len(items)), nil)
+}
+
+// updateAdopt handles adopt screen updates
+func (m *Model) updateAdopt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form := m.adoptForm
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || form == nil {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, keys.Back):
+		m.adoptForm = nil
+		m.screen = ScreenDrift
+		return m, nil
+	case key.Matches(keyMsg, keys.Tab):
+		if !form.remove {
+			form.setFocus(form.focus + 1)
+		}
+		return m, nil
+	case key.Matches(keyMsg, keys.Enter):
+		if form.remove {
+			return m.removeSelected()
+		}
+		return m.adoptSelected()
+	}
+
+	var cmd tea.Cmd
+	switch form.focus {
+	case 1:
+		form.product, cmd = form.product.Update(msg)
+		return m, cmd
+	case 2:
+		form.description, cmd = form.description.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, keys.Up):
+		if form.cursor > 0 {
+			form.cursor--
+		}
+	case key.Matches(keyMsg, keys.Down):
+		if form.cursor < len(form.items)-1 {
+			form.cursor++
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys(" ", "x"))):
+		form.selected[form.cursor] = !form.selected[form.cursor]
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("a"))):
+		all := len(form.Selection()) < len(form.items)
+		for i := range form.selected {
+			form.selected[i] = all
+		}
+	}
+	return m, nil
+}
+
+// viewAdopt renders the adopt screen
+func (m *Model) viewAdopt() string {
+	form := m.adoptForm
+	title := styles.Title.Render("Adopt Unmanaged Rules")
+	if form.remove {
+		title = styles.Title.Render("Remove Unmanaged Rules")
+	}
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d of %d selected", len(form.Selection()), len(form.items)))
+
+	limit := m.getRulesVisibleHeight()
+	start := 0
+	if form.cursor >= limit {
+		start = form.cursor - limit + 1
+	}
+
+	var lines []string
+	for i := start; i < len(form.items) && i < start+limit; i++ {
+		box := "[ ]"
+		if form.selected[i] {
+			box = "[x]"
+		}
+		op, _ := form.items[i].LiveOperation()
+		line := fmt.Sprintf("%s %s  (id %s)", box, op.String(), drift.StableID(form.items[i]))
+		if i == form.cursor && form.focus == 0 {
+			lines = append(lines, styles.ActiveMenuItem.Render(line))
+		} else {
+			lines = append(lines, styles.MenuItem.Render(line))
+		}
+	}
+
+	help := styles.Help.Render("space: toggle • a: toggle all • tab: product/description • enter: adopt • esc: back")
+	if form.remove {
+		help = styles.Help.Render("space: toggle • a: toggle all • enter: remove from firewall • esc: back")
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			subtitle,
+			"",
+			styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+			"",
+			help,
+		)
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		form.product.View(),
+		form.description.View(),
+		"",
+		help,
+	)
+}
@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/backup"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// backupListMsg carries the archives found in the backup directory
+type backupListMsg struct {
+	files []string
+	err   error
+}
+
+// restorePlanMsg carries the validated diff for a selected archive
+type restorePlanMsg struct {
+	plan *backup.Plan
+	err  error
+}
+
+// loadBackups lists the available archives
+func (m *Model) loadBackups() tea.Cmd {
+	return func() tea.Msg {
+		files, err := backup.List()
+		return backupListMsg{files, err}
+	}
+}
+
+// createBackup writes a new archive to the backup directory
+func (m *Model) createBackup() (tea.Model, tea.Cmd) {
+	m.loadingMsg = "Creating backup..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		secMgr, _ := security.NewManager()
+		path, err := backup.Save(m.ctx, m.provider, m.stateMgr, secMgr, "")
+		if err != nil {
+			return errMsg{err}
+		}
+		return successMsg{fmt.Sprintf("Backup written to %s", path)}
+	}
+}
+
+// planRestore opens the selected archive and diffs it against the system
+func (m *Model) planRestore() tea.Cmd {
+	if m.backupCursor >= len(m.backupFiles) {
+		return nil
+	}
+	path := m.backupFiles[m.backupCursor]
+	return func() tea.Msg {
+		archive, err := backup.Open(path)
+		if err != nil {
+			return restorePlanMsg{err: err}
+		}
+		secMgr, _ := security.NewManager()
+		plan, err := backup.NewPlan(m.ctx, m.provider, m.stateMgr, secMgr, archive)
+		return restorePlanMsg{plan, err}
+	}
+}
+
+// applyRestore restores the planned archive
+func (m *Model) applyRestore() (tea.Model, tea.Cmd) {
+	plan := m.restorePlan
+	m.restorePlan = nil
+	m.loadingMsg = "Restoring backup..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		secMgr, _ := security.NewManager()
+		if err := backup.Restore(m.ctx, m.provider, m.stateMgr, secMgr, plan); err != nil {
+			return errMsg{err}
+		}
+		return successMsg{fmt.Sprintf("Restored backup from %s", plan.Archive.Manifest.CreatedAt)}
+	}
+}
+
+// updateBackup handles backup screen updates
+func (m *Model) updateBackup(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case backupListMsg:
+		m.backupFiles = msg.files
+		m.backupErr = msg.err
+		m.backupCursor = 0
+		return m, nil
+
+	case restorePlanMsg:
+		m.restorePlan = msg.plan
+		m.backupErr = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.restorePlan != nil {
+			switch {
+			case key.Matches(msg, key.NewBinding(key.WithKeys("y"))):
+				return m.applyRestore()
+			case key.Matches(msg, keys.Back), key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+				m.restorePlan = nil
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, keys.Back):
+			m.screen = ScreenMenu
+			m.backupErr = nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("b"))):
+			return m.createBackup()
+		case key.Matches(msg, keys.Enter):
+			return m, m.planRestore()
+		case key.Matches(msg, keys.Up):
+			if m.backupCursor > 0 {
+				m.backupCursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.backupCursor < len(m.backupFiles)-1 {
+				m.backupCursor++
+			}
+		}
+	}
+	return m, nil
+}
+
+// viewBackup renders the archive list or the restore diff
+func (m *Model) viewBackup() string {
+	if m.restorePlan != nil {
+		return m.viewRestorePlan()
+	}
+
+	title := styles.Title.Render("Backup & Restore")
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d backups", len(m.backupFiles)))
+
+	var lines []string
+	if m.backupErr != nil {
+		lines = append(lines, styles.Error.Render(m.backupErr.Error()))
+	}
+	if len(m.backupFiles) == 0 {
+		lines = append(lines, styles.Info.Render("No backups yet"))
+	}
+	for i, file := range m.backupFiles {
+		if i == m.backupCursor {
+			lines = append(lines, styles.ActiveMenuItem.Render(filepath.Base(file)))
+		} else {
+			lines = append(lines, styles.MenuItem.Render(filepath.Base(file)))
+		}
+	}
+
+	help := styles.Help.Render("b: create backup • enter: preview restore • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
+
+// viewRestorePlan renders what a restore would change
+func (m *Model) viewRestorePlan() string {
+	plan := m.restorePlan
+	manifest := plan.Archive.Manifest
+	title := styles.Title.Render("Restore Preview")
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%s from %s (%s)", manifest.CreatedAt, manifest.Hostname, manifest.Provider))
+
+	var lines []string
+	for _, warning := range plan.Warnings {
+		lines = append(lines, styles.Warning.Render("! "+warning))
+	}
+	if plan.Empty() {
+		lines = append(lines, styles.Success.Render("✓ Firewall already matches the backup"))
+	}
+	for _, op := range plan.Operations {
+		lines = append(lines, styles.Info.Render(op.String()))
+	}
+	for _, label := range plan.Labels {
+		lines = append(lines, styles.Info.Render(fmt.Sprintf("selinux label %s %d/%s", label.Type, label.Port, label.Proto)))
+	}
+	lines = append(lines, styles.Help.Render(fmt.Sprintf("state: %d rules will replace the current state", len(plan.State.Rules))))
+
+	help := styles.Help.Render("y: restore • n/esc: cancel")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
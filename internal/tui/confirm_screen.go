@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/config"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// pendingMsg carries a change that must be confirmed before its deadline,
+// or the outcome of reverting one left over from an earlier session
+type pendingMsg struct {
+	pending  *transaction.Pending
+	reverted bool
+	err      error
+	// result, done and after finish the change once it is confirmed
+	result *transaction.Result
+	done   string
+	after  func() error
+}
+
+// confirmTickMsg drives the confirmation countdown
+type confirmTickMsg struct{}
+
+func confirmTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return confirmTickMsg{}
+	})
+}
+
+// confirmTimeout returns how long a change applied over SSH waits for
+// confirmation
+func (m *Model) confirmTimeout() time.Duration {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.DefaultConfirmTimeout
+	}
+	return cfg.ConfirmTimeout
+}
+
+// commitChange commits a staged change and reports done. Over SSH a bad
+// rule can cut the session, so the change is applied in confirm mode and
+// done is shown once it is kept; a change the SSH guard refuses is offered
+// for a forced retry. after runs once the change is final.
+func (m *Model) commitChange(txn *transaction.Transaction, loading, done string, after func() error) (tea.Model, tea.Cmd) {
+	m.loadingMsg = loading
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		if platform.CurrentSSHConnection() != nil {
+			pending, result, err := txn.CommitConfirmed(m.ctx, m.confirmTimeout())
+			if errors.Is(err, drivers.ErrSSHLockout) {
+				return lockoutMsg{txn: txn, err: err, loading: loading, done: done, after: after}
+			}
+			if err != nil {
+				return errMsg{err}
+			}
+			return pendingMsg{pending: pending, result: result, done: done, after: after}
+		}
+
+		result, err := txn.Commit(m.ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+		return m.finishChange(result, done, after)
+	}
+}
+
+// finishChange runs after, then reports done with the outcome of the
+// security policies and the journal and, when enabled, the reachability of
+// the new rules
+func (m *Model) finishChange(result *transaction.Result, done string, after func() error) tea.Msg {
+	if after != nil {
+		if err := after(); err != nil {
+			return errMsg{err}
+		}
+	}
+	done = withResultNotes(done, result)
+	if report := m.verifyResult(result); report != nil {
+		return verifiedMsg{done, report}
+	}
+	return successMsg{done}
+}
+
+// checkPendingChange resumes a change left unconfirmed by an earlier
+// session, reverting it if its deadline has already passed
+func (m *Model) checkPendingChange() tea.Cmd {
+	return func() tea.Msg {
+		reverted, err := transaction.RevertExpired(m.ctx, m.provider, m.stateMgr)
+		if err != nil || reverted {
+			return pendingMsg{reverted: reverted, err: err}
+		}
+
+		pending, err := transaction.LoadPending()
+		return pendingMsg{pending: pending, err: err}
+	}
+}
+
+// handlePending switches to the confirmation prompt for a pending change
+func (m *Model) handlePending(msg pendingMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.lastError = msg.err
+		m.screen = ScreenError
+	case msg.reverted:
+		m.successMsg = "Unconfirmed change from a previous session was reverted"
+		m.screen = ScreenSuccess
+	case msg.pending != nil:
+		m.pendingChange = msg.pending
+		m.pendingResult = msg.result
+		m.pendingDone = msg.done
+		m.pendingAfter = msg.after
+		m.screen = ScreenConfirmChange
+		m.addRuleForm.Reset()
+		return m, confirmTick()
+	}
+	return m, nil
+}
+
+// updateConfirmChange handles the confirmation prompt
+func (m *Model) updateConfirmChange(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case confirmTickMsg:
+		if m.pendingChange == nil {
+			return m, nil
+		}
+		if m.pendingChange.Expired() {
+			return m.revertPendingChange("Confirmation timed out, reverting change...", false)
+		}
+		return m, confirmTick()
+
+	case tea.KeyMsg:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("y", "enter"))) {
+			return m.confirmPendingChange()
+		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("n", "esc"))) {
+			return m.revertPendingChange("Reverting change...", false)
+		}
+	}
+	return m, nil
+}
+
+// confirmPendingChange keeps the pending change and finishes it
+func (m *Model) confirmPendingChange() (tea.Model, tea.Cmd) {
+	result, done, after := m.pendingResult, m.pendingDone, m.pendingAfter
+	m.clearPendingChange()
+	if err := transaction.Confirm(); err != nil {
+		m.lastError = err
+		m.screen = ScreenError
+		return m, nil
+	}
+	if done == "" {
+		done = "Change confirmed"
+	}
+
+	m.loadingMsg = "Finishing change..."
+	m.screen = ScreenLoading
+	return m, func() tea.Msg {
+		return m.finishChange(result, done, after)
+	}
+}
+
+// revertPendingChange reverts the pending change, quitting afterwards
+// when quit is set
+func (m *Model) revertPendingChange(loadingMsg string, quit bool) (tea.Model, tea.Cmd) {
+	m.clearPendingChange()
+	m.loadingMsg = loadingMsg
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		if err := transaction.RevertPending(m.ctx, m.provider, m.stateMgr); err != nil {
+			return errMsg{err}
+		}
+		if quit {
+			return tea.Quit()
+		}
+		return successMsg{"Change reverted"}
+	}
+}
+
+func (m *Model) clearPendingChange() {
+	m.pendingChange = nil
+	m.pendingResult = nil
+	m.pendingDone = ""
+	m.pendingAfter = nil
+}
+
+// viewConfirmChange renders the confirmation prompt with its countdown
+func (m *Model) viewConfirmChange() string {
+	title := styles.Title.Render("Confirm Change")
+
+	var lines []string
+	if m.pendingChange != nil {
+		for _, op := range m.pendingChange.Operations {
+			lines = append(lines, "  "+op.String())
+		}
+		remaining := m.pendingChange.Remaining().Round(time.Second)
+		lines = append(lines, "", styles.Warning.Render(
+			fmt.Sprintf("Reverting automatically in %s unless confirmed", remaining)))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	help := styles.Help.Render("y/enter: keep change • n/esc: revert now • ctrl+c: revert and quit")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		styles.Subtitle.Render("Applied over SSH - confirm you can still reach this host"),
+		"",
+		styles.Panel.Render(content),
+		"",
+		help,
+	)
+}
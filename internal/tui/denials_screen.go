@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/denials"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/security"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// denialsReportMsg carries AVC denials joined with the managed ports
+type denialsReportMsg struct {
+	report *denials.Report
+	err    error
+}
+
+// trackedSecurity returns a security manager recording its changes in state
+func (m *Model) trackedSecurity() (*security.Manager, error) {
+	secMgr, err := security.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	if m.stateMgr != nil {
+		secMgr.SetStore(m.stateMgr)
+	}
+	return secMgr, nil
+}
+
+// loadDenials reads the audit log for denials on managed ports
+func (m *Model) loadDenials() tea.Cmd {
+	return func() tea.Msg {
+		secMgr, err := m.trackedSecurity()
+		if err != nil {
+			return denialsReportMsg{err: err}
+		}
+		report, err := denials.Build(m.ctx, secMgr, m.stateMgr)
+		return denialsReportMsg{report, err}
+	}
+}
+
+// applyFix applies the selected suggested fix
+func (m *Model) applyFix() (tea.Model, tea.Cmd) {
+	fixes := m.denialsReport.Fixes()
+	if m.denialsCursor >= len(fixes) {
+		return m, nil
+	}
+	if !platform.IsRoot() {
+		m.lastError = fmt.Errorf("root privileges required to change SELinux policy")
+		m.screen = ScreenError
+		return m, nil
+	}
+	fix := fixes[m.denialsCursor]
+	m.loadingMsg = "Applying " + fix.String() + "..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			secMgr, err := m.trackedSecurity()
+			if err == nil {
+				err = fix.Apply(ctx, secMgr)
+			}
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Applied %s", fix.String()), nil
+		})
+	}
+}
+
+// updateDenials handles AVC denial screen updates
+func (m *Model) updateDenials(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case denialsReportMsg:
+		if msg.err != nil {
+			m.lastError = msg.err
+			m.screen = ScreenError
+			return m, nil
+		}
+		m.denialsReport = msg.report
+		m.denialsCursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			m.screen = ScreenSecurity
+			return m, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			m.denialsReport = nil
+			return m, m.loadDenials()
+		}
+		if m.denialsReport == nil {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.denialsCursor > 0 {
+				m.denialsCursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.denialsCursor < len(m.denialsReport.Fixes())-1 {
+				m.denialsCursor++
+			}
+		case key.Matches(msg, keys.Enter):
+			return m.applyFix()
+		}
+	}
+	return m, nil
+}
+
+// viewDenials renders denials per product with their suggested fixes
+func (m *Model) viewDenials() string {
+	title := styles.Title.Render("SELinux Denials")
+
+	if m.denialsReport == nil {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			styles.Info.Render("Reading audit log..."),
+		)
+	}
+
+	report := m.denialsReport
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d products with denials, %d denials on other ports",
+		len(report.Products), report.Unmanaged))
+
+	var lines []string
+	if report.Empty() {
+		lines = append(lines, styles.Success.Render("✓ No denials on ports managed by Portly"))
+	}
+	fixIdx := 0
+	for _, summary := range report.Products {
+		heading := summary.Product
+		if heading == "" {
+			heading = "(no product)"
+		}
+		lines = append(lines, styles.Subtitle.Render(heading))
+		for _, d := range summary.Denials {
+			line := fmt.Sprintf("  %s denied %s on %d/%s (%s, port type %s) ×%d",
+				d.Command, d.Permission, d.Port, d.Proto, d.SourceType, d.TargetType, d.Count)
+			if d.Permissive {
+				line += " [permissive]"
+			}
+			lines = append(lines, styles.Error.Render(line))
+		}
+		if len(summary.Fixes) == 0 {
+			lines = append(lines, styles.Info.Render("  No fix known; see audit2allow"))
+		}
+		for _, fix := range summary.Fixes {
+			line := "  fix: " + fix.String()
+			if fixIdx == m.denialsCursor {
+				lines = append(lines, styles.Success.Render("> "+line))
+			} else {
+				lines = append(lines, styles.Info.Render("  "+line))
+			}
+			fixIdx++
+		}
+		lines = append(lines, "")
+	}
+
+	help := styles.Help.Render("↑/↓: select fix • enter: apply fix • r: refresh • esc: back")
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
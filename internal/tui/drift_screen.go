@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/drift"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// driftReportMsg carries the result of a drift check
+type driftReportMsg struct {
+	report *drift.Report
+	err    error
+}
+
+// loadDriftReport compares state with the live firewall
+func (m *Model) loadDriftReport() tea.Cmd {
+	return func() tea.Msg {
+		report, err := drift.Detect(m.ctx, m.provider, m.stateMgr)
+		return driftReportMsg{report, err}
+	}
+}
+
+// reconcileDrift resolves the current report with the given policy
+func (m *Model) reconcileDrift(policy drift.Policy, loadingMsg string) (tea.Model, tea.Cmd) {
+	if m.driftReport == nil || m.driftReport.InSync() {
+		return m, nil
+	}
+	report := m.driftReport
+	m.loadingMsg = loadingMsg
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		if _, err := drift.Reconcile(m.ctx, m.provider, m.stateMgr, report, policy); err != nil {
+			return errMsg{err}
+		}
+		return successMsg{"Firewall reconciled with state"}
+	}
+}
+
+// updateDrift handles drift screen updates
+func (m *Model) updateDrift(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case driftReportMsg:
+		if msg.err != nil {
+			m.lastError = msg.err
+			m.screen = ScreenError
+			return m, nil
+		}
+		m.driftReport = msg.report
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.loadDriftReport()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("f"))):
+			return m.reconcileDrift(drift.Policy{Restore: true, Unmanaged: drift.UnmanagedIgnore},
+				"Re-applying missing rules...")
+		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+			return m.reconcileDrift(drift.Policy{Unmanaged: drift.UnmanagedAdopt},
+				"Adopting unmanaged rules...")
+		case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+			return m.openAdopt(false)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			return m.openAdopt(true)
+		}
+	}
+	return m, nil
+}
+
+// viewDrift renders the drift report
+func (m *Model) viewDrift() string {
+	title := styles.Title.Render("Drift Check")
+
+	if m.driftReport == nil {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			styles.Info.Render("Comparing state with the live firewall..."),
+		)
+	}
+
+	report := m.driftReport
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d missing, %d modified, %d unmanaged",
+		report.Count(drift.KindMissing), report.Count(drift.KindModified), report.Count(drift.KindExtra)))
+
+	var lines []string
+	if report.InSync() {
+		lines = append(lines, styles.Success.Render("✓ State and firewall are in sync"))
+	}
+
+	limit := m.getRulesVisibleHeight()
+	for i, item := range report.Items {
+		if i == limit {
+			lines = append(lines, styles.Help.Render(fmt.Sprintf("… %d more", len(report.Items)-limit)))
+			break
+		}
+		style := styles.Warning
+		switch item.Kind {
+		case drift.KindMissing:
+			style = styles.Error
+		case drift.KindExtra:
+			style = styles.Info
+		}
+		lines = append(lines, style.Render(item.String()))
+	}
+
+	help := styles.Help.Render("f: restore missing/modified • a: adopt all unmanaged • i: select rules to adopt • x: select rules to remove • r: refresh • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/bundle"
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/importer"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// exportListMsg carries the bundles found in the export directory
+type exportListMsg struct {
+	files []string
+	err   error
+}
+
+// importPlanMsg carries what importing a selected bundle would add
+type importPlanMsg struct {
+	plan *bundle.Plan
+	err  error
+}
+
+// newImportPath creates the input for a foreign configuration to import
+func newImportPath() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Import file: "
+	input.Placeholder = "iptables-save output, nft ruleset or docker-compose.yml"
+	return input
+}
+
+// importProducts returns the product catalog's default ports by name
+func importProducts() importer.Products {
+	products := make(importer.Products)
+	for _, p := range catalog.Default().Products() {
+		products[p.Name] = p.DefaultPorts()
+	}
+	return products
+}
+
+// loadExports lists the available bundles
+func (m *Model) loadExports() tea.Cmd {
+	return func() tea.Msg {
+		files, err := bundle.List()
+		return exportListMsg{files, err}
+	}
+}
+
+// createExport renders the managed rules in the selected format
+func (m *Model) createExport() (tea.Model, tea.Cmd) {
+	format := bundle.Formats[m.exportFormat]
+	m.loadingMsg = fmt.Sprintf("Exporting rules as %s...", format)
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		path, err := bundle.Save(m.provider, m.stateMgr, format, "")
+		if err != nil {
+			return errMsg{err}
+		}
+		return successMsg{fmt.Sprintf("Exported %s bundle to %s", format, path)}
+	}
+}
+
+// planImport opens the selected bundle and diffs it against the firewall
+func (m *Model) planImport() tea.Cmd {
+	if m.exportCursor >= len(m.exportFiles) {
+		return nil
+	}
+	path := m.exportFiles[m.exportCursor]
+	return func() tea.Msg {
+		b, err := bundle.Open(path)
+		if err != nil {
+			return importPlanMsg{err: err}
+		}
+		plan, err := bundle.NewPlan(m.ctx, m.provider, b)
+		return importPlanMsg{plan, err}
+	}
+}
+
+// planFileImport converts a foreign configuration and diffs it against the
+// firewall
+func (m *Model) planFileImport(path string) tea.Cmd {
+	return func() tea.Msg {
+		b, err := importer.Load(path, importProducts())
+		if err != nil {
+			return importPlanMsg{err: err}
+		}
+		plan, err := bundle.NewPlan(m.ctx, m.provider, b)
+		return importPlanMsg{plan, err}
+	}
+}
+
+// applyImport applies the planned bundle
+func (m *Model) applyImport() (tea.Model, tea.Cmd) {
+	plan := m.importPlan
+	m.importPlan = nil
+	m.loadingMsg = "Importing rules..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		if err := bundle.Import(m.ctx, m.provider, m.stateMgr, plan); err != nil {
+			return errMsg{err}
+		}
+		return successMsg{fmt.Sprintf("Imported %d rule(s) from %s", len(plan.Operations), plan.Bundle.Manifest.Hostname)}
+	}
+}
+
+// updateExport handles export screen updates
+func (m *Model) updateExport(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case exportListMsg:
+		m.exportFiles = msg.files
+		m.exportErr = msg.err
+		m.exportCursor = 0
+		return m, nil
+
+	case importPlanMsg:
+		m.importPlan = msg.plan
+		m.exportErr = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.importPlan != nil {
+			switch {
+			case key.Matches(msg, key.NewBinding(key.WithKeys("y"))):
+				if !m.importPlan.Empty() {
+					return m.applyImport()
+				}
+			case key.Matches(msg, keys.Back), key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+				m.importPlan = nil
+			}
+			return m, nil
+		}
+
+		if m.importPath.Focused() {
+			switch {
+			case key.Matches(msg, keys.Enter):
+				m.importPath.Blur()
+				return m, m.planFileImport(m.importPath.Value())
+			case key.Matches(msg, keys.Back):
+				m.importPath.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.importPath, cmd = m.importPath.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, keys.Back):
+			m.screen = ScreenMenu
+			m.exportErr = nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+			return m, m.importPath.Focus()
+		case key.Matches(msg, keys.Tab):
+			m.exportFormat = (m.exportFormat + 1) % len(bundle.Formats)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			return m.createExport()
+		case key.Matches(msg, keys.Enter):
+			return m, m.planImport()
+		case key.Matches(msg, keys.Up):
+			if m.exportCursor > 0 {
+				m.exportCursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.exportCursor < len(m.exportFiles)-1 {
+				m.exportCursor++
+			}
+		}
+	}
+	return m, nil
+}
+
+// viewExport renders the format selector and the bundle list, or the
+// import preview
+func (m *Model) viewExport() string {
+	if m.importPlan != nil {
+		return m.viewImportPlan()
+	}
+
+	title := styles.Title.Render("Export & Import")
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("Export format: %s", bundle.Formats[m.exportFormat]))
+
+	var lines []string
+	if m.exportErr != nil {
+		lines = append(lines, styles.Error.Render(m.exportErr.Error()))
+	}
+	if len(m.exportFiles) == 0 {
+		lines = append(lines, styles.Info.Render("No bundles yet"))
+	}
+	for i, file := range m.exportFiles {
+		if i == m.exportCursor {
+			lines = append(lines, styles.ActiveMenuItem.Render(filepath.Base(file)))
+		} else {
+			lines = append(lines, styles.MenuItem.Render(filepath.Base(file)))
+		}
+	}
+
+	help := styles.Help.Render("tab: change format • x: export • enter: preview import • o: import other file • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		m.importPath.View(),
+		"",
+		help,
+	)
+}
+
+// viewImportPlan renders what an import would add
+func (m *Model) viewImportPlan() string {
+	plan := m.importPlan
+	manifest := plan.Bundle.Manifest
+	title := styles.Title.Render("Import Preview")
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d rules from %s (%s), exported %s",
+		len(plan.Bundle.Rules), manifest.Hostname, manifest.Provider, manifest.CreatedAt))
+
+	var lines []string
+	for _, warning := range plan.Warnings {
+		lines = append(lines, styles.Warning.Render("! "+warning))
+	}
+	if plan.Empty() {
+		lines = append(lines, styles.Success.Render("✓ Every rule in the bundle is already present"))
+	}
+	for _, op := range plan.Operations {
+		lines = append(lines, styles.Info.Render(op.String()))
+	}
+	for _, skipped := range plan.Skipped {
+		lines = append(lines, styles.Help.Render(skipped))
+	}
+
+	help := styles.Help.Render("y: import • n/esc: cancel")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/catalog"
+	"github.com/orchestrator/unified-firewall/internal/expose"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// exposeGroupsMsg carries the rule groups in state
+type exposeGroupsMsg struct {
+	groups []expose.Group
+}
+
+// ExposeForm picks a catalog product and where to expose its ports
+type ExposeForm struct {
+	products   []catalog.Product
+	cursor     int
+	focus      int // 0 product, 1 internal IP, 2 source IP
+	internalIP textinput.Model
+	sourceIP   textinput.Model
+}
+
+// NewExposeForm creates a form over the catalog's products with ports
+func NewExposeForm() *ExposeForm {
+	f := &ExposeForm{}
+	for _, p := range catalog.Default().Products() {
+		if len(p.Ports) > 0 {
+			f.products = append(f.products, p)
+		}
+	}
+
+	f.internalIP = textinput.New()
+	f.internalIP.Prompt = "Forward to IP: "
+	f.internalIP.Placeholder = "empty opens the ports on this host"
+	f.sourceIP = textinput.New()
+	f.sourceIP.Prompt = "Only from IP:  "
+	f.sourceIP.Placeholder = "empty allows any source"
+	return f
+}
+
+// options returns the expose options entered in the form
+func (f *ExposeForm) options() expose.Options {
+	return expose.Options{
+		InternalIP: strings.TrimSpace(f.internalIP.Value()),
+		SourceIP:   strings.TrimSpace(f.sourceIP.Value()),
+	}
+}
+
+// nextFocus moves focus to the next field
+func (f *ExposeForm) nextFocus() tea.Cmd {
+	f.focus = (f.focus + 1) % 3
+	f.internalIP.Blur()
+	f.sourceIP.Blur()
+	switch f.focus {
+	case 1:
+		return f.internalIP.Focus()
+	case 2:
+		return f.sourceIP.Focus()
+	}
+	return nil
+}
+
+// loadExposeGroups lists the rule groups
+func (m *Model) loadExposeGroups() tea.Cmd {
+	return func() tea.Msg {
+		if m.stateMgr == nil {
+			return exposeGroupsMsg{}
+		}
+		return exposeGroupsMsg{expose.List(m.stateMgr)}
+	}
+}
+
+// groupError shows an error for a group action
+func (m *Model) groupError(err error) (tea.Model, tea.Cmd) {
+	m.lastError = err
+	m.screen = ScreenError
+	return m, nil
+}
+
+// submitExpose exposes the selected product
+func (m *Model) submitExpose() (tea.Model, tea.Cmd) {
+	form := m.exposeForm
+	m.exposeForm = nil
+	if !platform.IsRoot() {
+		return m.groupError(fmt.Errorf("root privileges required"))
+	}
+	if m.provider == nil {
+		return m.groupError(fmt.Errorf("no firewall provider available"))
+	}
+	if form.cursor >= len(form.products) {
+		return m.groupError(fmt.Errorf("no product selected"))
+	}
+
+	product := form.products[form.cursor]
+	txn, group, err := expose.Expose(m.provider, m.stateMgr, product.Name, form.options())
+	if err != nil {
+		return m.groupError(err)
+	}
+	return m.commitChange(txn,
+		fmt.Sprintf("Exposing %s...", product.Title()),
+		fmt.Sprintf("Exposed %s as group %s", product.Title(), group),
+		nil)
+}
+
+// toggleGroup disables an active group or re-enables a disabled one
+func (m *Model) toggleGroup() (tea.Model, tea.Cmd) {
+	if m.exposeCursor >= len(m.exposeGroups) || m.provider == nil {
+		return m, nil
+	}
+	g := m.exposeGroups[m.exposeCursor]
+
+	if g.State() == expose.StateActive {
+		txn, err := expose.Disable(m.provider, m.stateMgr, g.Name)
+		if err != nil {
+			return m.groupError(err)
+		}
+		return m.commitChange(txn, fmt.Sprintf("Disabling %s...", g.Name), fmt.Sprintf("Disabled group %s", g.Name), nil)
+	}
+
+	txn, err := expose.Enable(m.provider, m.stateMgr, g.Name)
+	if err != nil {
+		return m.groupError(err)
+	}
+	return m.commitChange(txn, fmt.Sprintf("Enabling %s...", g.Name), fmt.Sprintf("Enabled group %s", g.Name), nil)
+}
+
+// removeGroup removes every rule of the selected group
+func (m *Model) removeGroup() (tea.Model, tea.Cmd) {
+	if m.exposeCursor >= len(m.exposeGroups) || m.provider == nil {
+		return m, nil
+	}
+	g := m.exposeGroups[m.exposeCursor]
+
+	txn, err := expose.Remove(m.provider, m.stateMgr, g.Name)
+	if err != nil {
+		return m.groupError(err)
+	}
+	// Disabled rules are not on the firewall, so only state is updated
+	forget := func() error {
+		return expose.Forget(m.stateMgr, g.Name)
+	}
+	return m.commitChange(txn, fmt.Sprintf("Removing %s...", g.Name), fmt.Sprintf("Removed group %s", g.Name), forget)
+}
+
+// updateExpose handles product group screen updates
+func (m *Model) updateExpose(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case exposeGroupsMsg:
+		m.exposeGroups = msg.groups
+		m.exposeCursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.exposeForm != nil {
+			return m.updateExposeForm(msg)
+		}
+
+		switch {
+		case key.Matches(msg, keys.Back):
+			m.screen = ScreenMenu
+		case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+			m.exposeForm = NewExposeForm()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("e"))):
+			return m.toggleGroup()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			return m.removeGroup()
+		case key.Matches(msg, keys.Up):
+			if m.exposeCursor > 0 {
+				m.exposeCursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if m.exposeCursor < len(m.exposeGroups)-1 {
+				m.exposeCursor++
+			}
+		}
+	}
+	return m, nil
+}
+
+// updateExposeForm handles keys while picking a product to expose
+func (m *Model) updateExposeForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.exposeForm
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.exposeForm = nil
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		return m.submitExpose()
+	case key.Matches(msg, keys.Tab):
+		return m, form.nextFocus()
+	}
+
+	var cmd tea.Cmd
+	switch form.focus {
+	case 0:
+		switch {
+		case key.Matches(msg, keys.Up):
+			if form.cursor > 0 {
+				form.cursor--
+			}
+		case key.Matches(msg, keys.Down):
+			if form.cursor < len(form.products)-1 {
+				form.cursor++
+			}
+		}
+	case 1:
+		form.internalIP, cmd = form.internalIP.Update(msg)
+	case 2:
+		form.sourceIP, cmd = form.sourceIP.Update(msg)
+	}
+	return m, cmd
+}
+
+// viewExpose renders the rule groups, or the expose form
+func (m *Model) viewExpose() string {
+	if m.exposeForm != nil {
+		return m.viewExposeForm()
+	}
+
+	title := styles.Title.Render("Products")
+	subtitle := styles.Subtitle.Render("Rule groups created by exposing a product")
+
+	var lines []string
+	if len(m.exposeGroups) == 0 {
+		lines = append(lines, styles.Info.Render("No product groups yet"))
+	}
+	for i, g := range m.exposeGroups {
+		line := fmt.Sprintf("%-24s %-10s %d rule(s)", g.Name, g.State(), len(g.Rules))
+		if i == m.exposeCursor {
+			lines = append(lines, styles.ActiveMenuItem.Render(line))
+		} else {
+			lines = append(lines, styles.MenuItem.Render(line))
+		}
+	}
+
+	if m.exposeCursor < len(m.exposeGroups) {
+		lines = append(lines, "")
+		for _, rule := range m.exposeGroups[m.exposeCursor].Rules {
+			lines = append(lines, styles.Help.Render(fmt.Sprintf("  %s  %s  [%s]", rule.ID, rule.Description, rule.Status)))
+		}
+	}
+
+	help := styles.Help.Render("↑/↓: select • n: expose product • e: enable/disable • x: remove • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
+
+// viewExposeForm renders the product picker
+func (m *Model) viewExposeForm() string {
+	form := m.exposeForm
+	title := styles.Title.Render("Expose Product")
+	subtitle := styles.Subtitle.Render("Apply all of a product's ports as one group")
+
+	var lines []string
+	for i, p := range form.products {
+		var ports []string
+		for _, port := range p.Ports {
+			ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+		}
+		line := fmt.Sprintf("%-20s %s", p.Title(), strings.Join(ports, ", "))
+		if i == form.cursor {
+			lines = append(lines, styles.ActiveMenuItem.Render(line))
+		} else {
+			lines = append(lines, styles.MenuItem.Render(line))
+		}
+	}
+
+	help := styles.Help.Render("↑/↓: product • tab: next field • enter: expose • esc: cancel")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		form.internalIP.View(),
+		form.sourceIP.View(),
+		"",
+		help,
+	)
+}
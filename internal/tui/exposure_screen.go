@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/exposure"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// exposureReportMsg carries the result of an exposure check
+type exposureReportMsg struct {
+	report *exposure.Report
+	err    error
+}
+
+// loadExposureReport joins listening sockets with the live rules
+func (m *Model) loadExposureReport() tea.Cmd {
+	return func() tea.Msg {
+		report, err := exposure.Build(m.ctx, m.provider)
+		return exposureReportMsg{report, err}
+	}
+}
+
+// updateExposure handles exposure screen updates
+func (m *Model) updateExposure(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case exposureReportMsg:
+		if msg.err != nil {
+			m.lastError = msg.err
+			m.screen = ScreenError
+			return m, nil
+		}
+		m.exposureReport = msg.report
+		return m, nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("r"))) {
+			m.exposureReport = nil
+			return m, m.loadExposureReport()
+		}
+	}
+	return m, nil
+}
+
+// viewExposure renders the exposure report
+func (m *Model) viewExposure() string {
+	title := styles.Title.Render("Exposure Report")
+
+	if m.exposureReport == nil {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			title,
+			"",
+			styles.Info.Render("Reading listening sockets..."),
+		)
+	}
+
+	report := m.exposureReport
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d listening sockets, %d findings, %d remote forwards not checked",
+		len(report.Listeners), len(report.Findings), report.Unchecked))
+
+	var lines []string
+	if report.Clean() {
+		lines = append(lines, styles.Success.Render("✓ Every open port and forward has a listener"))
+	}
+	sections := []struct {
+		kind    exposure.Kind
+		heading string
+		style   lipgloss.Style
+	}{
+		{exposure.KindNotListening, "Open but nothing listening", styles.Warning},
+		{exposure.KindFirewalled, "Listening on all addresses but firewalled", styles.Info},
+		{exposure.KindDeadForward, "Forwards without a listener", styles.Error},
+	}
+	for _, section := range sections {
+		findings := report.ByKind(section.kind)
+		if len(findings) == 0 {
+			continue
+		}
+		lines = append(lines, styles.Subtitle.Render(section.heading))
+		for _, f := range findings {
+			lines = append(lines, section.style.Render("  "+f.String()))
+		}
+	}
+
+	help := styles.Help.Render("r: refresh • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/orchestrator/unified-firewall/internal/container"
+	"github.com/orchestrator/unified-firewall/internal/follow"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+)
+
+// followEventMsg reports what the background container watch changed
+type followEventMsg struct {
+	changes []follow.Change
+	err     error
+}
+
+// watchContainers follows container IP changes in the background for as
+// long as the TUI runs. Without root, state or a container runtime there
+// is nothing to follow and no watch is started.
+func (m *Model) watchContainers() tea.Cmd {
+	if m.provider == nil || m.stateMgr == nil || !platform.IsRoot() {
+		return nil
+	}
+	events := make(chan followEventMsg, 8)
+	m.followEvents = events
+
+	go func() {
+		defer close(events)
+		err := follow.Watch(m.ctx, m.provider, m.stateMgr, func(changes []follow.Change, err error) {
+			if len(changes) > 0 || err != nil {
+				events <- followEventMsg{changes, err}
+			}
+		})
+		// A host without podman or docker has nothing to watch
+		if err != nil && m.ctx.Err() == nil && !errors.Is(err, container.ErrNoRuntime) {
+			events <- followEventMsg{err: err}
+		}
+	}()
+	return m.nextFollowEvent()
+}
+
+// nextFollowEvent waits for the next report of the container watch
+func (m *Model) nextFollowEvent() tea.Cmd {
+	events := m.followEvents
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// handleFollowEvent shows the latest container watch report in the status
+// bar and keeps listening
+func (m *Model) handleFollowEvent(msg followEventMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.followNotice = fmt.Sprintf("container follow failed: %v", msg.err)
+	} else {
+		lines := make([]string, 0, len(msg.changes))
+		for _, change := range msg.changes {
+			lines = append(lines, change.String())
+		}
+		m.followNotice = "followed " + strings.Join(lines, ", ")
+	}
+	return m, m.nextFollowEvent()
+}
+
+// followContainers re-points container NAT rules whose container moved
+func (m *Model) followContainers() (tea.Model, tea.Cmd) {
+	if m.provider == nil || m.stateMgr == nil {
+		return m, nil
+	}
+	m.loadingMsg = "Checking container addresses..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		changes, err := follow.Reconcile(m.ctx, m.provider, m.stateMgr)
+		if err != nil {
+			return errMsg{err}
+		}
+		if len(changes) == 0 {
+			return successMsg{"All container rules point at their container"}
+		}
+		lines := make([]string, 0, len(changes))
+		for _, change := range changes {
+			lines = append(lines, change.String())
+		}
+		return successMsg{fmt.Sprintf("Updated %d container rule(s):\n%s", len(changes), strings.Join(lines, "\n"))}
+	}
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/orchestrator/unified-firewall/internal/conflict"
+	"github.com/orchestrator/unified-firewall/internal/container"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -25,6 +27,16 @@ type AddRuleForm struct {
 	cancelled   bool
 	optionFocus int
 	lastProduct string // Track last selected product to detect changes
+
+	// Container picker for NAT targets; containerFocus is -1 when hidden
+	containerTargets []container.Target
+	containerErr     error
+	containerFocus   int
+	target           *container.Target
+
+	// Conflicts found for the rule as entered when they were checked
+	conflicts    []conflict.Conflict
+	conflictsFor string
 }
 
 // NewAddRuleForm creates a new add rule form
@@ -56,6 +68,8 @@ func NewAddRuleForm() *AddRuleForm {
 		focus:       0,
 		optionFocus: -1,
 		lastProduct: "",
+
+		containerFocus: -1,
 	}
 	fields[0].Focus()
 	return form
@@ -147,6 +161,7 @@ func (f *AddRuleForm) GetNATRule() (models.NATRule, error) {
 		InternalPort: internalPort,
 		Proto:        proto,
 		Description:  f.fields[6].Value(), // Index changed to 6
+		Container:    f.containerName(),
 	}
 	// Return rule without calling .Validate() here
 	return rule, nil
@@ -191,6 +206,10 @@ func (f *AddRuleForm) Reset() {
 	f.focus = 0
 	f.optionFocus = -1
 	f.lastProduct = ""
+	f.containerFocus = -1
+	f.target = nil
+	f.conflicts = nil
+	f.conflictsFor = ""
 	f.fields[0].Focus()
 	f.completed = false
 	f.cancelled = false
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"encoding/json"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/conflict"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// conflictsMsg carries the conflicts of the rule the form describes
+type conflictsMsg struct {
+	fingerprint string
+	conflicts   []conflict.Conflict
+	err         error
+}
+
+// operation returns the operation the form would stage
+func (f *AddRuleForm) operation() models.Operation {
+	if f.formType == FormTypeNAT {
+		rule, _ := f.GetNATRule()
+		return models.Operation{Kind: models.OpApplyNAT, NAT: &rule}
+	}
+	rule, _ := f.GetFirewallRule()
+	return models.Operation{Kind: models.OpOpenPort, Firewall: &rule}
+}
+
+// fingerprint identifies the rule the form describes, to tell whether
+// conflicts found earlier still apply
+func (f *AddRuleForm) fingerprint() string {
+	data, _ := json.Marshal(f.operation())
+	return string(data)
+}
+
+// currentConflicts returns the conflicts found for the rule as it is now
+// entered, and whether it has been checked
+func (f *AddRuleForm) currentConflicts() ([]conflict.Conflict, bool) {
+	if f.conflictsFor == "" || f.conflictsFor != f.fingerprint() {
+		return nil, false
+	}
+	return f.conflicts, true
+}
+
+// checkAddRule checks the entered rule for conflicts before submitting.
+// Warnings are shown inline and submitting again applies the rule anyway;
+// errors must be fixed first.
+func (m *Model) checkAddRule() (tea.Model, tea.Cmd) {
+	form := m.addRuleForm
+	if conflicts, checked := form.currentConflicts(); checked {
+		if conflict.HasErrors(conflicts) {
+			return m, nil
+		}
+		return m.submitAddRule()
+	}
+	if m.provider == nil {
+		return m.submitAddRule()
+	}
+
+	op, fingerprint := form.operation(), form.fingerprint()
+	return m, func() tea.Msg {
+		conflicts, err := conflict.Check(m.ctx, m.provider, op)
+		return conflictsMsg{fingerprint, conflicts, err}
+	}
+}
+
+// handleConflicts submits a rule without conflicts or shows them
+func (m *Model) handleConflicts(msg conflictsMsg) (tea.Model, tea.Cmd) {
+	form := m.addRuleForm
+	if msg.fingerprint != form.fingerprint() {
+		// The form changed while checking
+		return m, nil
+	}
+	// A failed check should not stand in the way; the provider still
+	// rejects rules it cannot apply
+	if msg.err != nil || len(msg.conflicts) == 0 {
+		return m.submitAddRule()
+	}
+	form.conflicts = msg.conflicts
+	form.conflictsFor = msg.fingerprint
+	return m, nil
+}
+
+// renderConflicts renders the conflicts of the entered rule
+func renderConflicts(conflicts []conflict.Conflict) string {
+	lines := []string{styles.Subtitle.Render("Conflicts")}
+	for _, c := range conflicts {
+		if c.Severity == conflict.SeverityError {
+			lines = append(lines, styles.Error.Render("✗ "+c.Message))
+		} else {
+			lines = append(lines, styles.Warning.Render("! "+c.Message))
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
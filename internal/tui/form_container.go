@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/container"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// containersMsg carries the container ports a NAT rule can target
+type containersMsg struct {
+	targets []container.Target
+	err     error
+}
+
+// loadContainers discovers running podman and docker containers
+func (m *Model) loadContainers() tea.Cmd {
+	return func() tea.Msg {
+		containers, err := container.List(m.ctx)
+		var targets []container.Target
+		for i := range containers {
+			targets = append(targets, containers[i].Targets()...)
+		}
+		return containersMsg{targets, err}
+	}
+}
+
+// ShowContainers opens the container picker with the discovered targets
+func (f *AddRuleForm) ShowContainers(msg containersMsg) {
+	f.containerTargets = msg.targets
+	f.containerErr = msg.err
+	f.containerFocus = 0
+}
+
+// ShowingContainers returns if the container picker is visible
+func (f *AddRuleForm) ShowingContainers() bool {
+	return f.containerFocus >= 0
+}
+
+// HideContainers closes the container picker
+func (f *AddRuleForm) HideContainers() {
+	f.containerFocus = -1
+}
+
+// MoveContainerFocus moves the picker selection by delta, wrapping around
+func (f *AddRuleForm) MoveContainerFocus(delta int) {
+	if len(f.containerTargets) == 0 {
+		return
+	}
+	f.containerFocus = (f.containerFocus + delta + len(f.containerTargets)) % len(f.containerTargets)
+}
+
+// SelectContainer fills the internal IP, port and protocol from the
+// highlighted container target
+func (f *AddRuleForm) SelectContainer() {
+	if f.containerFocus >= len(f.containerTargets) {
+		f.HideContainers()
+		return
+	}
+	target := f.containerTargets[f.containerFocus]
+	f.target = &target
+	f.fields[2].SetValue(target.IP)
+	if target.Port > 0 {
+		f.fields[3].SetValue(strconv.Itoa(target.Port))
+		f.fields[4].SetValue(string(target.Protocol))
+		if f.fields[1].Value() == "" {
+			f.fields[1].SetValue(strconv.Itoa(target.Port))
+		}
+	}
+	if f.fields[6].Value() == "" {
+		f.fields[6].SetValue("container " + target.Container)
+	}
+	f.HideContainers()
+}
+
+// containerName returns the selected container if the internal IP still
+// points at it
+func (f *AddRuleForm) containerName() string {
+	if f.target == nil || f.fields[2].Value() != f.target.IP {
+		return ""
+	}
+	return f.target.Container
+}
+
+// renderContainerPicker renders the discovered container targets
+func renderContainerPicker(form *AddRuleForm) string {
+	var lines []string
+	if form.containerErr != nil {
+		lines = append(lines, styles.Error.Render(form.containerErr.Error()))
+	} else if len(form.containerTargets) == 0 {
+		lines = append(lines, styles.Info.Render("No running containers with an IP address"))
+	}
+	for i, target := range form.containerTargets {
+		optStyle := lipgloss.NewStyle()
+		if i == form.containerFocus {
+			optStyle = optStyle.Background(lipgloss.Color(styles.PrimaryColor)).Foreground(lipgloss.Color("#FFFFFF"))
+		} else {
+			optStyle = optStyle.Foreground(lipgloss.Color(styles.TextColor))
+		}
+		lines = append(lines, optStyle.Render("  "+target.String()))
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color(styles.BorderColor)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 )
 
 // ProductInfo holds default configuration for a product
@@ -15,19 +16,16 @@ type ProductInfo struct {
 	DefaultPorts []int
 }
 
-// ProductDatabase contains default configurations for known products
-var ProductDatabase = map[string]ProductInfo{
-	"podman":    {Name: "podman", Description: "Container engine", DefaultPorts: []int{8080, 8443}},
-	"docker":    {Name: "docker", Description: "Container platform", DefaultPorts: []int{8080, 443}},
-	"tailscale": {Name: "tailscale", Description: "VPN mesh network", DefaultPorts: []int{41641}},
-	"headscale": {Name: "headscale", Description: "Self-hosted Tailscale", DefaultPorts: []int{8080}},
-	"twingate":  {Name: "twingate", Description: "Zero trust network", DefaultPorts: []int{443}},
-	"steam":     {Name: "steam", Description: "Gaming platform", DefaultPorts: []int{27015}},
-	"minecraft": {Name: "minecraft", Description: "Minecraft server", DefaultPorts: []int{25565}},
-	"nginx":     {Name: "nginx", Description: "Web server", DefaultPorts: []int{80, 443}},
-	"postgres":  {Name: "postgres", Description: "PostgreSQL database", DefaultPorts: []int{5432}},
-	"redis":     {Name: "redis", Description: "Redis cache", DefaultPorts: []int{6379}},
-	"custom":    {Name: "custom", Description: "Custom product", DefaultPorts: []int{}},
+// lookupProduct returns the catalog entry for a product name
+func lookupProduct(name string) (ProductInfo, bool) {
+	if name == "custom" {
+		return ProductInfo{Name: "custom", Description: "Custom product", DefaultPorts: []int{}}, true
+	}
+	p, ok := catalog.Default().Get(name)
+	if !ok {
+		return ProductInfo{}, false
+	}
+	return ProductInfo{Name: p.Name, Description: p.Description, DefaultPorts: p.DefaultPorts()}, true
 }
 
 // NewProductField creates a product selector field with default options
@@ -35,17 +33,14 @@ func NewProductField(label string, required bool) EnhancedFormField {
 	input := textinput.New()
 	input.Placeholder = "Select or type custom product"
 
-	// Build display options from database
+	// Build display options from the product catalog
 	var options []string
-	for key, info := range ProductDatabase {
-		if key == "custom" {
-			options = append(options, "custom       - Type your own product name")
-		} else {
-			portsStr := formatPorts(info.DefaultPorts)
-			option := fmt.Sprintf("%-12s - %s (ports: %s)", key, info.Description, portsStr)
-			options = append(options, option)
-		}
+	for _, p := range catalog.Default().Products() {
+		portsStr := formatPorts(p.DefaultPorts())
+		option := fmt.Sprintf("%-12s - %s (ports: %s)", p.Name, p.Description, portsStr)
+		options = append(options, option)
 	}
+	options = append(options, "custom       - Type your own product name")
 
 	return EnhancedFormField{
 		label:       label,
@@ -80,7 +75,7 @@ func (f *EnhancedFormField) GetProductInfo() ProductInfo {
 			fields := strings.Fields(opt)
 			if len(fields) > 0 {
 				name := fields[0]
-				if info, ok := ProductDatabase[name]; ok {
+				if info, ok := lookupProduct(name); ok {
 					return info
 				}
 			}
@@ -88,7 +83,7 @@ func (f *EnhancedFormField) GetProductInfo() ProductInfo {
 	}
 
 	// Check if it's a direct product name
-	if info, ok := ProductDatabase[val]; ok {
+	if info, ok := lookupProduct(val); ok {
 		return info
 	}
 
@@ -1,12 +1,12 @@
 package tui
 
 import (
-	"context"
 	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -36,9 +36,9 @@ func (m *Model) submitAddRule() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// 1. Get the populated rule data based on type
+	// 1. Stage the populated rule data based on type
 	var ruleID string
-	var operation func(context.Context) error
+	txn := transaction.New(m.provider, m.stateMgr)
 
 	if m.addRuleForm.formType == FormTypeNAT {
 		var rule models.NATRule
@@ -50,9 +50,7 @@ func (m *Model) submitAddRule() (tea.Model, tea.Cmd) {
 			m.screen = ScreenError
 			return m, nil
 		}
-		operation = func(ctx context.Context) error {
-			return m.provider.ApplyNAT(ctx, rule)
-		}
+		txn.ApplyNAT(rule)
 	} else {
 		var rule models.FirewallRule
 		rule, _ = m.addRuleForm.GetFirewallRule()
@@ -63,35 +61,23 @@ func (m *Model) submitAddRule() (tea.Model, tea.Cmd) {
 			m.screen = ScreenError
 			return m, nil
 		}
-
-		if rule.Type == models.RuleTypePortLimit {
-			operation = func(ctx context.Context) error {
-				return m.provider.OpenPortForIP(ctx, rule)
-			}
-		} else if rule.Type == models.RuleTypeTrustIP {
-			operation = func(ctx context.Context) error {
-				return m.provider.TrustIP(ctx, rule)
-			}
-		} else {
-			operation = func(ctx context.Context) error {
-				return m.provider.OpenPort(ctx, rule)
-			}
-		}
+		txn.OpenPort(rule)
 	}
 
-	m.loadingMsg = "Applying rule..."
-	m.screen = ScreenLoading
-
-	return m, func() tea.Msg {
-		err := operation(m.ctx)
-		if err != nil {
-			return errMsg{err}
-		}
-
-		// Since we don't have a generic AddRule to stateMgr yet for other types,
-		// we might need to skip stateMgr update for non-NAT or fit it in.
-		// For now we just focus on applying the rule.
+	return m.commitChange(txn, "Applying rule...", fmt.Sprintf("Rule %s created successfully", ruleID), nil)
+}
 
-		return successMsg{fmt.Sprintf("Rule %s created successfully", ruleID)}
+// withResultNotes appends security policy and journal failures to a
+// success message
+func withResultNotes(msg string, result *transaction.Result) string {
+	if result == nil {
+		return msg
+	}
+	if result.SecurityErr != nil {
+		msg = fmt.Sprintf("%s\n\nSecurity policy not fully applied: %v", msg, result.SecurityErr)
+	}
+	if err := result.JournalErr(); err != nil {
+		msg = fmt.Sprintf("%s\n\nWarning: %v", msg, err)
 	}
+	return msg
 }
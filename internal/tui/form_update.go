@@ -12,7 +12,28 @@ func (m *Model) updateAddRule(msg tea.Msg) (tea.Model, tea.Cmd) {
 	form := m.addRuleForm
 
 	switch msg := msg.(type) {
+	case containersMsg:
+		form.ShowContainers(msg)
+		return m, nil
+
+	case conflictsMsg:
+		return m.handleConflicts(msg)
+
 	case tea.KeyMsg:
+		if form.ShowingContainers() {
+			switch {
+			case key.Matches(msg, keys.Enter):
+				form.SelectContainer()
+			case key.Matches(msg, keys.Up):
+				form.MoveContainerFocus(-1)
+			case key.Matches(msg, keys.Down):
+				form.MoveContainerFocus(1)
+			case key.Matches(msg, keys.Back), key.Matches(msg, keys.Tab):
+				form.HideContainers()
+			}
+			return m, nil
+		}
+
 		// Handle backspace to delete characters
 		if msg.Type == tea.KeyBackspace {
 			var cmd tea.Cmd
@@ -40,7 +61,7 @@ func (m *Model) updateAddRule(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if form.focus == len(form.fields)-1 {
-				return m.submitAddRule()
+				return m.checkAddRule()
 			}
 			return m, form.NextField()
 
@@ -77,6 +98,11 @@ func (m *Model) updateAddRule(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+t"))):
+			if form.formType == FormTypeNAT {
+				return m, m.loadContainers()
+			}
+
 		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+d"))):
 			if form.IsProductField() {
 				form.ShowProductOptions()
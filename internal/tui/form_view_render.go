@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/conflict"
 	"github.com/orchestrator/unified-firewall/internal/tui/styles"
 )
 
@@ -85,14 +86,31 @@ func (m *Model) viewAddRule() string {
 			fieldContent = renderProductDropdown(fieldContent, field, form.optionFocus)
 		}
 
+		// Show container picker under the internal IP
+		if i == 2 && form.ShowingContainers() {
+			fieldContent = lipgloss.JoinVertical(lipgloss.Left, fieldContent, renderContainerPicker(form))
+		}
+
 		fields = append(fields, fieldContent)
 	}
 
 	formContent := lipgloss.JoinVertical(lipgloss.Left, fields...)
+	conflicts, _ := form.currentConflicts()
+	if len(conflicts) > 0 {
+		formContent = lipgloss.JoinVertical(lipgloss.Left, formContent, "", renderConflicts(conflicts))
+	}
 
 	var help string
 	if form.ShowingOptions() {
 		help = styles.Help.Render("↑/↓: select • enter: confirm • tab: close")
+	} else if len(conflicts) > 0 && conflict.HasErrors(conflicts) {
+		help = styles.Help.Render("fix the conflicts to submit • tab: next • esc: back")
+	} else if len(conflicts) > 0 {
+		help = styles.Help.Render("enter: submit anyway • tab: next • esc: back")
+	} else if form.ShowingContainers() {
+		help = styles.Help.Render("↑/↓: select container • enter: use • esc: close")
+	} else if form.formType == FormTypeNAT {
+		help = styles.Help.Render("tab: next • enter: submit • esc: back • Ctrl+D: product list • Ctrl+T: containers")
 	} else if form.formType == FormTypeOpenIP {
 		// Product field not visible for OpenIP
 		help = styles.Help.Render("tab: next • enter: submit • esc: back")
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// historyMsg carries journal entries matching the current filter
+type historyMsg struct {
+	entries []audit.Entry
+	err     error
+	verify  error
+}
+
+// newHistoryFilter creates the filter input of the history screen
+func newHistoryFilter() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Filter: "
+	input.Placeholder = "product:postgres port:5432 since:7d until:2026-01-31"
+	return input
+}
+
+// loadHistory reads the audit journal with the current filter
+func (m *Model) loadHistory() tea.Cmd {
+	query := m.historyFilter.Value()
+	return func() tea.Msg {
+		filter, err := audit.ParseFilter(query)
+		if err != nil {
+			return historyMsg{err: err}
+		}
+		journal := audit.Default()
+		entries, err := journal.Read(filter)
+		return historyMsg{entries, err, journal.Verify()}
+	}
+}
+
+// updateHistory handles history screen updates
+func (m *Model) updateHistory(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case historyMsg:
+		m.historyEntries = msg.entries
+		m.historyErr = msg.err
+		m.historyVerifyErr = msg.verify
+		m.historyScroll = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.historyFilter.Focused() {
+			switch {
+			case key.Matches(msg, keys.Enter):
+				m.historyFilter.Blur()
+				return m, m.loadHistory()
+			case key.Matches(msg, keys.Back):
+				m.historyFilter.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.historyFilter, cmd = m.historyFilter.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, keys.Back):
+			m.screen = ScreenMenu
+		case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+			return m, m.historyFilter.Focus()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.loadHistory()
+		case key.Matches(msg, keys.Down):
+			if m.historyScroll < len(m.historyEntries)-1 {
+				m.historyScroll++
+			}
+		case key.Matches(msg, keys.Up):
+			if m.historyScroll > 0 {
+				m.historyScroll--
+			}
+		}
+	}
+	return m, nil
+}
+
+// viewHistory renders the audit journal, newest entry first
+func (m *Model) viewHistory() string {
+	title := styles.Title.Render("Change History")
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("%d entries", len(m.historyEntries)))
+
+	var lines []string
+	if m.historyVerifyErr != nil {
+		lines = append(lines, styles.Error.Render("! "+m.historyVerifyErr.Error()))
+	}
+	if m.historyErr != nil {
+		lines = append(lines, styles.Error.Render(m.historyErr.Error()))
+	} else if len(m.historyEntries) == 0 {
+		lines = append(lines, styles.Info.Render("No changes recorded"))
+	}
+
+	limit := m.getRulesVisibleHeight()
+	for i := m.historyScroll; i < len(m.historyEntries) && i < m.historyScroll+limit; i++ {
+		entry := m.historyEntries[len(m.historyEntries)-1-i]
+		style := styles.Success
+		if entry.Result != audit.ResultSuccess {
+			style = styles.Error
+		}
+		line := entry.Summary()
+		if len(entry.Commands) > 0 {
+			line += fmt.Sprintf(" [%d commands]", len(entry.Commands))
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	help := styles.Help.Render("/: filter • ↑/↓: scroll • r: refresh • esc: back")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		subtitle,
+		"",
+		m.historyFilter.View(),
+		styles.Panel.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		"",
+		help,
+	)
+}
@@ -32,8 +32,14 @@ func New(ctx context.Context) (*Model, error) {
 	items := []list.Item{
 		menuItem{"Add Rule Setup", "Configure new firewall rules", ScreenAddRuleSelect},
 		menuItem{"List Rules", "View and manage existing rules", ScreenListRules},
+		menuItem{"Products", "Expose a product's ports as one group", ScreenExpose},
 		menuItem{"Firewall", "Start/stop or install firewall", ScreenFirewall},
 		menuItem{"Security", "Manage SELinux/AppArmor", ScreenSecurity},
+		menuItem{"Drift Check", "Compare tracked rules with the live firewall", ScreenDrift},
+		menuItem{"Exposure Report", "Compare listening services with open ports and forwards", ScreenExposure},
+		menuItem{"History", "Browse the audit journal of changes", ScreenHistory},
+		menuItem{"Backup & Restore", "Snapshot or restore everything Portly manages", ScreenBackup},
+		menuItem{"Export & Import", "Translate rules to another backend or import a bundle", ScreenExport},
 		menuItem{"System Status", "View system and provider status", ScreenStatus},
 		menuItem{"Check Configuration", "Verify system configuration", ScreenCheck},
 		menuItem{"Quit", "Exit Portly", -1},
@@ -76,10 +82,13 @@ func New(ctx context.Context) (*Model, error) {
 		ruleSubMenuList: subMenuList,
 		addRuleForm:     NewAddRuleForm(),
 		ruleViewMode:    "nat",
+		historyFilter:   newHistoryFilter(),
+		importPath:      newImportPath(),
+		booleanFilter:   newBooleanFilter(),
 	}, nil
 }
 
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
-	return m.addRuleForm.Init()
+	return tea.Batch(m.addRuleForm.Init(), m.checkPendingChange(), m.watchContainers())
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
 	"github.com/orchestrator/unified-firewall/internal/tui/styles"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
@@ -37,10 +38,32 @@ func (m *Model) loadAllRules() tea.Cmd {
 			}
 		}
 
+		m.annotateFromState(natRules, fwRules)
 		return allRulesMsg{natRules, fwRules}
 	}
 }
 
+// annotateFromState fills in product and description for rules Portly
+// tracks, since most backends do not store them
+func (m *Model) annotateFromState(natRules []models.NATRule, fwRules []models.FirewallRule) {
+	if m.stateMgr == nil {
+		return
+	}
+	for i := range natRules {
+		if tracked := m.stateMgr.FindNATRule(natRules[i]); tracked != nil {
+			natRules[i].Product = tracked.Product
+			natRules[i].Description = tracked.Description
+			natRules[i].Container = tracked.Container
+		}
+	}
+	for i := range fwRules {
+		if tracked := m.stateMgr.FindFirewallRule(fwRules[i]); tracked != nil {
+			fwRules[i].Product = tracked.Product
+			fwRules[i].Description = tracked.Description
+		}
+	}
+}
+
 // CombinedRule represents a unified view of any rule type
 type CombinedRule struct {
 	ID          string
@@ -97,6 +120,15 @@ func (m *Model) updateListRules(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key.Matches(msg, key.NewBinding(key.WithKeys("r"))) {
 			return m, m.loadAllRules()
 		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("f"))) {
+			return m.followContainers()
+		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("u"))) {
+			return m.undoLastChange()
+		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("U"))) {
+			return m.redoLastChange()
+		}
 		if key.Matches(msg, key.NewBinding(key.WithKeys("a"))) {
 			m.screen = ScreenAddRuleSelect
 			return m, nil
@@ -179,32 +211,18 @@ func (m *Model) updateListRules(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // deleteFirstRule deletes the first visible rule
 func (m *Model) deleteFirstRule() (tea.Model, tea.Cmd) {
+	txn := transaction.New(m.provider, m.stateMgr)
+
 	if m.ruleViewMode == "nat" && len(m.natRules) > 0 {
 		rule := m.natRules[0]
-		m.loadingMsg = fmt.Sprintf("Removing NAT rule %s...", rule.ID)
-		m.screen = ScreenLoading
-
-		return m, func() tea.Msg {
-			if m.provider != nil {
-				m.provider.RemoveNAT(m.ctx, rule.ID)
-			}
-			return successMsg{fmt.Sprintf("NAT rule %s removed", rule.ID)}
-		}
+		txn.RemoveNAT(rule)
+		return m.commitChange(txn, fmt.Sprintf("Removing NAT rule %s...", rule.ID), fmt.Sprintf("NAT rule %s removed", rule.ID), nil)
 	}
-
 	if m.ruleViewMode == "firewall" && len(m.firewallRules) > 0 {
 		rule := m.firewallRules[0]
-		m.loadingMsg = fmt.Sprintf("Removing firewall rule %s...", rule.ID)
-		m.screen = ScreenLoading
-
-		return m, func() tea.Msg {
-			if m.provider != nil {
-				m.provider.ClosePort(m.ctx, rule.ID)
-			}
-			return successMsg{fmt.Sprintf("Firewall rule %s removed", rule.ID)}
-		}
+		txn.ClosePort(rule)
+		return m.commitChange(txn, fmt.Sprintf("Removing firewall rule %s...", rule.ID), fmt.Sprintf("Firewall rule %s removed", rule.ID), nil)
 	}
-
 	return m, nil
 }
 
@@ -234,7 +252,7 @@ func (m *Model) viewNATRules(title, subtitle string) string {
 			msg = "No NAT rules found. Press 'a' to add a rule."
 		}
 		content := styles.Info.Render(msg)
-		help := styles.Help.Render("esc: back • a: add • r: refresh • tab: switch view")
+		help := styles.Help.Render("esc: back • a: add • u/U: undo/redo • r: refresh • tab: switch view")
 
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
@@ -289,12 +307,17 @@ func (m *Model) viewNATRules(title, subtitle string) string {
 		if product == "" {
 			product = "system"
 		}
+		// Show the container name instead of its address when known
+		target := rule.InternalIP
+		if rule.Container != "" {
+			target = rule.Container
+		}
 		row := lipgloss.JoinHorizontal(
 			lipgloss.Left,
 			styles.TableCell.Width(20).Render(displayID),
 			styles.TableCell.Width(12).Render(product),
 			styles.TableCell.Width(10).Render(fmt.Sprintf("%d", rule.ExternalPort)),
-			styles.TableCell.Width(22).Render(fmt.Sprintf("%s:%d", rule.InternalIP, rule.InternalPort)),
+			styles.TableCell.Width(22).Render(fmt.Sprintf("%s:%d", target, rule.InternalPort)),
 			styles.TableCell.Width(6).Render(string(rule.Proto)),
 		)
 		rows = append(rows, row)
@@ -306,7 +329,7 @@ func (m *Model) viewNATRules(title, subtitle string) string {
 	}
 
 	table := lipgloss.JoinVertical(lipgloss.Left, rows...)
-	help := styles.Help.Render("↑/↓/j/k: scroll • pgup/pgdn: page • home/end: jump • esc: back • d: delete first • r: refresh • tab: firewall view")
+	help := styles.Help.Render("↑/↓/j/k: scroll • pgup/pgdn: page • home/end: jump • esc: back • d: delete first • f: follow containers • u/U: undo/redo • r: refresh • tab: firewall view")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -329,7 +352,7 @@ func (m *Model) viewFirewallRules(title, subtitle string) string {
 			msg = "No firewall rules found. Press 'a' to add a rule."
 		}
 		content := styles.Info.Render(msg)
-		help := styles.Help.Render("esc: back • a: add • r: refresh • tab: switch view")
+		help := styles.Help.Render("esc: back • a: add • u/U: undo/redo • r: refresh • tab: switch view")
 
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
@@ -424,7 +447,7 @@ func (m *Model) viewFirewallRules(title, subtitle string) string {
 	}
 
 	table := lipgloss.JoinVertical(lipgloss.Left, rows...)
-	help := styles.Help.Render("↑/↓/j/k: scroll • pgup/pgdn: page • home/end: jump • esc: back • d: delete first • r: refresh • tab: NAT view")
+	help := styles.Help.Render("↑/↓/j/k: scroll • pgup/pgdn: page • home/end: jump • esc: back • d: delete first • u/U: undo/redo • r: refresh • tab: NAT view")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
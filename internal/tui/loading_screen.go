@@ -17,6 +17,12 @@ func (m *Model) updateLoading(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.screen = ScreenSuccess
 		m.addRuleForm.Reset()
 		return m, nil
+	case verifiedMsg:
+		m.successMsg = msg.msg
+		m.verifyReport = msg.report
+		m.screen = ScreenSuccess
+		m.addRuleForm.Reset()
+		return m, nil
 	}
 	return m, nil
 }
@@ -51,6 +57,9 @@ func (m *Model) viewError() string {
 func (m *Model) viewSuccess() string {
 	title := styles.Title.Render("Success")
 	success := styles.Success.Render(m.successMsg)
+	if m.verifyReport != nil {
+		success = lipgloss.JoinVertical(lipgloss.Left, success, "", renderVerifyReport(m.verifyReport))
+	}
 	help := styles.Help.Render("any key: menu")
 
 	return lipgloss.JoinVertical(
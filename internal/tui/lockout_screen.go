@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+)
+
+// lockoutMsg carries a change the SSH lockout guard refused, with what
+// commitChange needs to retry it
+type lockoutMsg struct {
+	txn     *transaction.Transaction
+	err     error
+	loading string
+	done    string
+	after   func() error
+}
+
+// handleLockout asks whether to force a change that would cut off the
+// current SSH session
+func (m *Model) handleLockout(msg lockoutMsg) (tea.Model, tea.Cmd) {
+	m.lockoutChange = &msg
+	m.screen = ScreenLockout
+	return m, nil
+}
+
+// updateLockout handles the lockout prompt
+func (m *Model) updateLockout(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.lockoutChange == nil {
+		return m, nil
+	}
+
+	change := m.lockoutChange
+	if key.Matches(keyMsg, key.NewBinding(key.WithKeys("y"))) {
+		m.lockoutChange = nil
+		// Forcing only skips the guard: the change is still applied in
+		// confirm mode and reverts unless the session survives it
+		change.txn.SetForce(true)
+		return m.commitChange(change.txn, change.loading, change.done, change.after)
+	}
+	if key.Matches(keyMsg, key.NewBinding(key.WithKeys("n", "esc"))) {
+		m.lockoutChange = nil
+		m.screen = ScreenMenu
+	}
+	return m, nil
+}
+
+// viewLockout renders the lockout prompt
+func (m *Model) viewLockout() string {
+	title := styles.Title.Render("SSH Lockout")
+
+	var content string
+	if m.lockoutChange != nil {
+		content = styles.Error.Render(m.lockoutChange.err.Error())
+	}
+	help := styles.Help.Render("y: apply anyway • n/esc: cancel")
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		styles.Subtitle.Render("This change would cut off your SSH session"),
+		"",
+		styles.Panel.Render(content),
+		"",
+		styles.Warning.Render("If applied, it reverts automatically unless confirmed"),
+		"",
+		help,
+	)
+}
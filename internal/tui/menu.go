@@ -23,6 +23,23 @@ func (m *Model) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 				switch item.screen {
 				case ScreenListRules:
 					return m, m.loadAllRules()
+				case ScreenDrift:
+					m.driftReport = nil
+					return m, m.loadDriftReport()
+				case ScreenExposure:
+					m.exposureReport = nil
+					return m, m.loadExposureReport()
+				case ScreenHistory:
+					return m, m.loadHistory()
+				case ScreenBackup:
+					m.restorePlan = nil
+					return m, m.loadBackups()
+				case ScreenExport:
+					m.importPlan = nil
+					return m, m.loadExports()
+				case ScreenExpose:
+					m.exposeForm = nil
+					return m, m.loadExposeGroups()
 				case ScreenStatus:
 					return m, nil
 				case ScreenCheck:
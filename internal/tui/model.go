@@ -5,9 +5,19 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/backup"
+	"github.com/orchestrator/unified-firewall/internal/bundle"
+	"github.com/orchestrator/unified-firewall/internal/denials"
+	"github.com/orchestrator/unified-firewall/internal/drift"
 	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/expose"
+	"github.com/orchestrator/unified-firewall/internal/exposure"
 	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/internal/verify"
 	"github.com/orchestrator/unified-firewall/pkg/models"
 )
 
@@ -30,6 +40,16 @@ const (
 	ScreenLoading
 	ScreenError
 	ScreenSuccess
+	ScreenConfirmChange
+	ScreenDrift
+	ScreenAdopt
+	ScreenHistory
+	ScreenBackup
+	ScreenExport
+	ScreenExpose
+	ScreenExposure
+	ScreenDenials
+	ScreenLockout
 )
 
 // Model is the main TUI model
@@ -45,6 +65,9 @@ type Model struct {
 	lastError  error
 	successMsg string
 
+	// Reachability of the rules behind the current success message
+	verifyReport *verify.Report
+
 	menuItems []list.Item
 	menuList  list.Model
 
@@ -64,8 +87,63 @@ type Model struct {
 	// Security rules storage
 	seLinuxBooleans      []SELinuxBoolean
 	appArmorProfiles     []AppArmorProfile
+	securityRulesErr     error
 	securitySelectionIdx int
 	securityScrollOffset int
+	booleanFilter        textinput.Model
+	// Whether SELinux mode changes are written to the SELinux config
+	selinuxPersist bool
+
+	// Differences between state and the live firewall
+	driftReport *drift.Report
+	adoptForm   *AdoptForm
+
+	// Listening sockets joined with the live rules
+	exposureReport *exposure.Report
+
+	// AVC denials on managed ports and the selected fix
+	denialsReport *denials.Report
+	denialsCursor int
+
+	// Audit journal view
+	historyEntries   []audit.Entry
+	historyErr       error
+	historyVerifyErr error
+	historyFilter    textinput.Model
+	historyScroll    int
+
+	// Backup archives and the restore being previewed
+	backupFiles  []string
+	backupCursor int
+	backupErr    error
+	restorePlan  *backup.Plan
+
+	// Export bundles, the chosen format and the import being previewed
+	exportFormat int
+	exportFiles  []string
+	exportCursor int
+	exportErr    error
+	importPath   textinput.Model
+	importPlan   *bundle.Plan
+
+	// Product rule groups and the product being exposed
+	exposeGroups []expose.Group
+	exposeCursor int
+	exposeForm   *ExposeForm
+
+	// Reports of the background container watch and the latest one
+	followEvents <-chan followEventMsg
+	followNotice string
+
+	// Change awaiting confirmation before it is automatically reverted,
+	// with the message and follow-up step for when it is kept
+	pendingChange *transaction.Pending
+	pendingResult *transaction.Result
+	pendingDone   string
+	pendingAfter  func() error
+
+	// Change refused by the SSH lockout guard, kept for a forced retry
+	lockoutChange *lockoutMsg
 }
 
 type menuItem struct {
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// newBooleanFilter creates the search field of the boolean browser
+func newBooleanFilter() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Search: "
+	input.Placeholder = "name, description or product"
+	return input
+}
+
+// filteredBooleans returns the booleans matching the search field
+func (m *Model) filteredBooleans() []SELinuxBoolean {
+	query := strings.ToLower(strings.TrimSpace(m.booleanFilter.Value()))
+	if query == "" {
+		return m.seLinuxBooleans
+	}
+	var matches []SELinuxBoolean
+	for _, b := range m.seLinuxBooleans {
+		text := strings.ToLower(b.Name + " " + b.Description + " " + strings.Join(b.Products, " "))
+		if strings.Contains(text, query) {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -1,13 +1,54 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/platform"
+	"github.com/orchestrator/unified-firewall/internal/security"
 )
 
+// securityChange runs change with its commands recorded and journals them,
+// reporting done on success and noting a change that was not journaled
+func (m *Model) securityChange(change func(ctx context.Context) (string, error)) tea.Msg {
+	ctx, recorder := platform.WithCommandRecorder(m.ctx)
+	done, err := change(ctx)
+
+	entry := audit.Entry{
+		Action:   audit.ActionSecurity,
+		Commands: recorder.Records(),
+		Result:   audit.ResultSuccess,
+	}
+	if err != nil {
+		entry.Result = audit.ResultFailed
+		entry.Error = err.Error()
+	}
+	var journalErr error
+	if len(entry.Commands) > 0 {
+		journalErr = audit.Default().Append(entry)
+	}
+
+	if err != nil {
+		return errMsg{err}
+	}
+	if journalErr != nil {
+		done = fmt.Sprintf("%s\n\nWarning: change applied but not journaled: %v", done, journalErr)
+	}
+	return successMsg{done}
+}
+
+// runSecurityCommand runs a security tool, recording it for the journal
+func runSecurityCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	output, err := cmd.CombinedOutput()
+	platform.RecordCommand(ctx, cmd, output, err)
+	return output, err
+}
+
 // selinuxStatus holds SELinux state
 type selinuxStatus struct {
 	mode   string
@@ -43,23 +84,42 @@ func (m *Model) getSELinuxStatus() selinuxStatus {
 
 // toggleSELinux sets SELinux to enforcing
 func (m *Model) toggleSELinux() tea.Cmd {
-	return func() tea.Msg {
-		cmd := exec.Command("setenforce", "1")
-		if out, err := cmd.CombinedOutput(); err != nil {
-			return errMsg{fmt.Errorf("failed to set enforcing: %v (output: %s)", err, string(out))}
-		}
-		return successMsg{"SELinux set to enforcing mode"}
-	}
+	return m.setSELinuxMode(security.SELinuxEnforcing)
 }
 
 // setSELinuxPermissive sets SELinux to permissive
 func (m *Model) setSELinuxPermissive() tea.Cmd {
+	return m.setSELinuxMode(security.SELinuxPermissive)
+}
+
+// setSELinuxMode switches the running mode, also writing it to the SELinux
+// config when persistence is turned on
+func (m *Model) setSELinuxMode(mode string) tea.Cmd {
+	persist := m.selinuxPersist
 	return func() tea.Msg {
-		cmd := exec.Command("setenforce", "0")
-		if out, err := cmd.CombinedOutput(); err != nil {
-			return errMsg{fmt.Errorf("failed to set permissive: %v (output: %s)", err, string(out))}
-		}
-		return successMsg{"SELinux set to permissive mode"}
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			secMgr, err := security.NewManager()
+			if err != nil {
+				return "", err
+			}
+			rebootRequired, err := secMgr.SetSELinuxMode(ctx, mode, persist)
+			if err != nil {
+				return "", err
+			}
+			msg := fmt.Sprintf("SELinux set to %s mode", mode)
+			if persist {
+				msg += fmt.Sprintf("\n\nSaved to %s", security.SELinuxConfigPath)
+			} else {
+				msg += "\n\nThe change lasts until the next reboot"
+			}
+			if rebootRequired {
+				msg += "\n\nReboot required for the new mode to take effect"
+			}
+			if rebootRequired && mode != security.SELinuxDisabled {
+				msg += "\n\nThe filesystem will be relabeled on the next boot, which can take a while"
+			}
+			return msg, nil
+		})
 	}
 }
 
@@ -95,25 +155,25 @@ func (m *Model) getAppArmorStatus() appArmorStatus {
 // toggleAppArmor toggles AppArmor
 func (m *Model) toggleAppArmor() tea.Cmd {
 	return func() tea.Msg {
-		status := m.getAppArmorStatus()
-
-		var cmd *exec.Cmd
-		if status.loaded {
-			cmd = exec.Command("aa-teardown")
-		} else {
-			cmd = exec.Command("systemctl", "start", "apparmor")
-		}
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			status := m.getAppArmorStatus()
 
-		if cmd != nil {
-			if out, err := cmd.CombinedOutput(); err != nil {
-				return errMsg{fmt.Errorf("failed to toggle AppArmor: %v (output: %s)", err, string(out))}
+			var out []byte
+			var err error
+			if status.loaded {
+				out, err = runSecurityCommand(ctx, "aa-teardown")
+			} else {
+				out, err = runSecurityCommand(ctx, "systemctl", "start", "apparmor")
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to toggle AppArmor: %v (output: %s)", err, string(out))
 			}
-		}
 
-		action := "started"
-		if status.loaded {
-			action = "stopped"
-		}
-		return successMsg{fmt.Sprintf("AppArmor %s", action)}
+			action := "started"
+			if status.loaded {
+				action = "stopped"
+			}
+			return fmt.Sprintf("AppArmor %s", action), nil
+		})
 	}
 }
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/security"
 	"github.com/orchestrator/unified-firewall/internal/tui/styles"
 )
 
@@ -15,6 +17,7 @@ import (
 type securityRulesMsg struct {
 	seLinuxBooleans  []SELinuxBoolean
 	appArmorProfiles []AppArmorProfile
+	err              error
 }
 
 // SELinuxBoolean represents an SELinux boolean setting
@@ -23,6 +26,8 @@ type SELinuxBoolean struct {
 	State       string // on, off
 	Default     string
 	Description string
+	// Products lists the catalog products recommending the boolean
+	Products []string
 }
 
 // AppArmorProfile represents an AppArmor profile status
@@ -39,7 +44,7 @@ func (m *Model) loadSecurityRulesData() tea.Cmd {
 		msg := securityRulesMsg{}
 
 		if m.osInfo.IsRHEL() {
-			msg.seLinuxBooleans = m.getSELinuxBooleans()
+			msg.seLinuxBooleans, msg.err = m.getSELinuxBooleans()
 		} else if m.osInfo.IsDebian() {
 			msg.appArmorProfiles = m.getAppArmorProfiles()
 		}
@@ -48,41 +53,35 @@ func (m *Model) loadSecurityRulesData() tea.Cmd {
 	}
 }
 
-// getSELinuxBooleans gets relevant SELinux booleans
-func (m *Model) getSELinuxBooleans() []SELinuxBoolean {
-	var booleans []SELinuxBoolean
-
-	// List of container/network related booleans we care about
-	relevantBooleans := []string{
-		"container_manage_cgroup",
-		"container_use_cephfs",
-		"domain_can_mmap_files",
-		"httpd_can_network_connect",
-		"httpd_can_network_relay",
-		"nis_enabled",
-		"daemons_enable_cluster_mode",
+// getSELinuxBooleans lists every boolean of the loaded policy
+func (m *Model) getSELinuxBooleans() ([]SELinuxBoolean, error) {
+	secMgr, err := security.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	list, err := secMgr.ListSELinuxBooleans(m.ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, name := range relevantBooleans {
-		cmd := exec.Command("getsebool", name)
-		out, err := cmd.Output()
-		if err != nil {
-			continue
-		}
-
-		// Parse output like "container_manage_cgroup --> on"
-		parts := strings.Split(string(out), "-->")
-		if len(parts) == 2 {
-			state := strings.TrimSpace(parts[1])
-			booleans = append(booleans, SELinuxBoolean{
-				Name:    name,
-				State:   state,
-				Default: state,
-			})
-		}
+	booleans := make([]SELinuxBoolean, 0, len(list))
+	for _, b := range list {
+		booleans = append(booleans, SELinuxBoolean{
+			Name:        b.Name,
+			State:       onOff(b.On),
+			Default:     onOff(b.Default),
+			Description: b.Description,
+			Products:    b.Products,
+		})
 	}
+	return booleans, nil
+}
 
-	return booleans
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
 }
 
 // getAppArmorProfiles gets AppArmor profile status
@@ -143,23 +142,24 @@ func (m *Model) getAppArmorProfiles() []AppArmorProfile {
 // toggleSELinuxBoolean toggles an SELinux boolean on/off
 func (m *Model) toggleSELinuxBoolean(index int) tea.Cmd {
 	return func() tea.Msg {
-		if index >= len(m.seLinuxBooleans) {
+		booleans := m.filteredBooleans()
+		if index >= len(booleans) {
 			return errMsg{fmt.Errorf("invalid boolean index")}
 		}
 
-		boolean := m.seLinuxBooleans[index]
+		boolean := booleans[index]
 		newState := "on"
 		if boolean.State == "on" {
 			newState = "off"
 		}
 
-		cmd := exec.Command("setsebool", "-P", boolean.Name, newState)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return errMsg{fmt.Errorf("failed to toggle %s: %v (output: %s)", boolean.Name, err, string(output))}
-		}
-
-		return successMsg{fmt.Sprintf("%s set to %s", boolean.Name, newState)}
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			output, err := runSecurityCommand(ctx, "setsebool", "-P", boolean.Name, newState)
+			if err != nil {
+				return "", fmt.Errorf("failed to toggle %s: %v (output: %s)", boolean.Name, err, string(output))
+			}
+			return fmt.Sprintf("%s set to %s", boolean.Name, newState), nil
+		})
 	}
 }
 
@@ -176,20 +176,14 @@ func (m *Model) toggleAppArmorProfile(index int) tea.Cmd {
 			newMode = "complain"
 		}
 
-		// Use aa-complain or aa-enforce
-		var cmd *exec.Cmd
-		if newMode == "enforce" {
-			cmd = exec.Command("aa-enforce", profile.Name)
-		} else {
-			cmd = exec.Command("aa-complain", profile.Name)
-		}
-
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return errMsg{fmt.Errorf("failed to set %s to %s: %v (output: %s)", profile.Name, newMode, err, string(output))}
-		}
-
-		return successMsg{fmt.Sprintf("%s set to %s mode", profile.Name, newMode)}
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			// Use aa-complain or aa-enforce
+			output, err := runSecurityCommand(ctx, "aa-"+newMode, profile.Name)
+			if err != nil {
+				return "", fmt.Errorf("failed to set %s to %s: %v (output: %s)", profile.Name, newMode, err, string(output))
+			}
+			return fmt.Sprintf("%s set to %s mode", profile.Name, newMode), nil
+		})
 	}
 }
 
@@ -202,13 +196,13 @@ func (m *Model) disableAppArmorProfile(index int) tea.Cmd {
 
 		profile := m.appArmorProfiles[index]
 
-		cmd := exec.Command("aa-disable", profile.Name)
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return errMsg{fmt.Errorf("failed to disable %s: %v (output: %s)", profile.Name, err, string(output))}
-		}
-
-		return successMsg{fmt.Sprintf("%s disabled", profile.Name)}
+		return m.securityChange(func(ctx context.Context) (string, error) {
+			output, err := runSecurityCommand(ctx, "aa-disable", profile.Name)
+			if err != nil {
+				return "", fmt.Errorf("failed to disable %s: %v (output: %s)", profile.Name, err, string(output))
+			}
+			return fmt.Sprintf("%s disabled", profile.Name), nil
+		})
 	}
 }
 
@@ -240,12 +234,27 @@ func (m *Model) updateSecurityRules(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case securityRulesMsg:
 		m.seLinuxBooleans = msg.seLinuxBooleans
 		m.appArmorProfiles = msg.appArmorProfiles
+		m.securityRulesErr = msg.err
 		// Reset selection when loading new data
 		m.securitySelectionIdx = 0
 		m.securityScrollOffset = 0
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.booleanFilter.Focused() {
+			if key.Matches(msg, keys.Enter) || key.Matches(msg, keys.Back) {
+				m.booleanFilter.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.booleanFilter, cmd = m.booleanFilter.Update(msg)
+			m.securitySelectionIdx = 0
+			m.securityScrollOffset = 0
+			return m, cmd
+		}
+		if msg.String() == "/" && m.osInfo.IsRHEL() {
+			return m, m.booleanFilter.Focus()
+		}
 		if key.Matches(msg, keys.Back) {
 			m.screen = ScreenSecurity
 			m.securitySelectionIdx = 0
@@ -260,7 +269,7 @@ func (m *Model) updateSecurityRules(msg tea.Msg) (tea.Model, tea.Cmd) {
 		visibleHeight := m.getSecurityVisibleHeight()
 		var listLen int
 		if m.osInfo.IsRHEL() {
-			listLen = len(m.seLinuxBooleans)
+			listLen = len(m.filteredBooleans())
 		} else {
 			listLen = len(m.appArmorProfiles)
 		}
@@ -308,8 +317,8 @@ func (m *Model) updateSecurityRules(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Toggle action (Enter or Space)
 		if key.Matches(msg, keys.Enter) || msg.String() == " " {
-			if m.osInfo.IsRHEL() && len(m.seLinuxBooleans) > 0 {
-				m.loadingMsg = fmt.Sprintf("Toggling %s...", m.seLinuxBooleans[m.securitySelectionIdx].Name)
+			if booleans := m.filteredBooleans(); m.osInfo.IsRHEL() && len(booleans) > 0 {
+				m.loadingMsg = fmt.Sprintf("Toggling %s...", booleans[m.securitySelectionIdx].Name)
 				m.screen = ScreenLoading
 				return m, m.toggleSELinuxBoolean(m.securitySelectionIdx)
 			} else if m.osInfo.IsDebian() && len(m.appArmorProfiles) > 0 {
@@ -358,17 +367,24 @@ func (m *Model) viewSecurityRules() string {
 
 // viewSELinuxRules renders SELinux booleans
 func (m *Model) viewSELinuxRules(title string) string {
-	subtitle := styles.Subtitle.Render(fmt.Sprintf("SELinux Booleans (%d configured)", len(m.seLinuxBooleans)))
+	booleans := m.filteredBooleans()
+	subtitle := styles.Subtitle.Render(fmt.Sprintf("SELinux Booleans (%d of %d)", len(booleans), len(m.seLinuxBooleans)))
 
-	if len(m.seLinuxBooleans) == 0 {
+	if len(booleans) == 0 {
 		content := styles.Info.Render("No SELinux booleans found or SELinux is disabled.")
-		help := styles.Help.Render("esc: back • r: refresh")
+		if m.securityRulesErr != nil {
+			content = styles.Error.Render(m.securityRulesErr.Error())
+		} else if len(m.seLinuxBooleans) > 0 {
+			content = styles.Info.Render("No booleans match the search.")
+		}
+		help := styles.Help.Render("esc: back • /: search • r: refresh")
 
 		return lipgloss.JoinVertical(
 			lipgloss.Left,
 			title,
 			subtitle,
 			"",
+			m.booleanFilter.View(),
 			styles.Panel.Render(content),
 			"",
 			help,
@@ -378,8 +394,8 @@ func (m *Model) viewSELinuxRules(title string) string {
 	visibleHeight := m.getSecurityVisibleHeight()
 	startIdx := m.securityScrollOffset
 	endIdx := startIdx + visibleHeight
-	if endIdx > len(m.seLinuxBooleans) {
-		endIdx = len(m.seLinuxBooleans)
+	if endIdx > len(booleans) {
+		endIdx = len(booleans)
 	}
 
 	var rows []string
@@ -390,10 +406,12 @@ func (m *Model) viewSELinuxRules(title string) string {
 		styles.TableHeader.Width(5).Render(""),
 		styles.TableHeader.Width(35).Render("Boolean"),
 		styles.TableHeader.Width(10).Render("State"),
+		styles.TableHeader.Width(20).Render("Recommended For"),
+		styles.TableHeader.Width(50).Render("Description"),
 	)
 	rows = append(rows, header)
 	rows = append(rows, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.BorderColor)).Render(
-		strings.Repeat("─", 55),
+		strings.Repeat("─", 125),
 	))
 
 	// Show scroll indicators if needed
@@ -403,7 +421,7 @@ func (m *Model) viewSELinuxRules(title string) string {
 
 	// Visible rows only
 	for i := startIdx; i < endIdx; i++ {
-		b := m.seLinuxBooleans[i]
+		b := booleans[i]
 		stateStyle := styles.Error.Render
 		if b.State == "on" {
 			stateStyle = styles.Success.Render
@@ -420,23 +438,26 @@ func (m *Model) viewSELinuxRules(title string) string {
 			styles.TableCell.Width(5).Render(selector),
 			styles.TableCell.Width(35).Render(b.Name),
 			styles.TableCell.Width(10).Render(stateStyle(b.State)),
+			styles.TableCell.Width(20).Render(truncate(strings.Join(b.Products, ", "), 18)),
+			styles.TableCell.Width(50).Render(truncate(b.Description, 48)),
 		)
 		rows = append(rows, row)
 	}
 
 	// Show scroll indicators if needed
-	if endIdx < len(m.seLinuxBooleans) {
+	if endIdx < len(booleans) {
 		rows = append(rows, lipgloss.NewStyle().Foreground(lipgloss.Color(styles.MutedColor)).Render("↓ more below"))
 	}
 
 	table := lipgloss.JoinVertical(lipgloss.Left, rows...)
-	help := styles.Help.Render("↑/↓: navigate • pgup/pgdn: page • enter/space: toggle • r: refresh • esc: back")
+	help := styles.Help.Render("↑/↓: navigate • pgup/pgdn: page • enter/space: toggle • /: search • r: refresh • esc: back")
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		title,
 		subtitle,
 		"",
+		m.booleanFilter.View(),
 		styles.Panel.Render(table),
 		"",
 		help,
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/security"
 	"github.com/orchestrator/unified-firewall/internal/tui/styles"
 )
 
@@ -29,6 +30,15 @@ func (m *Model) updateSecurity(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.screen = ScreenLoading
 			return m, m.setSELinuxPermissive()
 		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("p"))) && m.osInfo.IsRHEL() {
+			m.selinuxPersist = !m.selinuxPersist
+			return m, nil
+		}
+		if key.Matches(msg, key.NewBinding(key.WithKeys("d"))) && m.osInfo.IsRHEL() {
+			m.denialsReport = nil
+			m.screen = ScreenDenials
+			return m, m.loadDenials()
+		}
 		if key.Matches(msg, key.NewBinding(key.WithKeys("a"))) && m.osInfo.IsDebian() {
 			m.loadingMsg = "Toggling AppArmor..."
 			m.screen = ScreenLoading
@@ -58,7 +68,9 @@ func (m *Model) viewSecurity() string {
 	}
 
 	var helpText string
-	if m.osInfo.IsRHEL() || m.osInfo.IsDebian() {
+	if m.osInfo.IsRHEL() {
+		helpText = "esc: back • v: view rules • d: denials"
+	} else if m.osInfo.IsDebian() {
 		helpText = "esc: back • v: view rules"
 	} else {
 		helpText = "esc: back"
@@ -88,15 +100,41 @@ func (m *Model) viewSELinux() string {
 		modeStr = styles.Error.Render(status.mode)
 	}
 
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
+	lines := []string{
 		"SELinux Status:",
 		fmt.Sprintf("  Mode: %s", modeStr),
 		fmt.Sprintf("  Policy: %s", status.policy),
-		"",
+	}
+	if secMgr, err := security.NewManager(); err == nil {
+		if configMode, err := secMgr.GetSELinuxConfigMode(); err == nil {
+			lines = append(lines, fmt.Sprintf("  At boot: %s", configMode))
+		}
+	}
+	lines = append(lines, "")
+	if m.stateMgr != nil {
+		if labels := m.stateMgr.ListPortLabels(); len(labels) > 0 {
+			lines = append(lines, "Port labels managed by Portly:")
+			for _, label := range labels {
+				line := fmt.Sprintf("  %d/%s %s", label.Port, label.Proto, label.Type)
+				if label.Product != "" {
+					line += fmt.Sprintf(" (%s)", label.Product)
+				}
+				if label.Previous != "" {
+					line += fmt.Sprintf(", was %s", label.Previous)
+				}
+				lines = append(lines, line)
+			}
+			lines = append(lines, "")
+		}
+	}
+	lines = append(lines,
 		styles.Button.Render("[1] Set Enforcing"),
 		styles.Button.Render("[2] Set Permissive"),
+		styles.Button.Render(fmt.Sprintf("[p] Persist Across Reboots: %s", onOff(m.selinuxPersist))),
+		styles.Button.Render("[d] Denials on Managed Ports"),
 	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 // viewAppArmor shows AppArmor management
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/catalog"
 	"github.com/orchestrator/unified-firewall/internal/installer"
 	"github.com/orchestrator/unified-firewall/internal/platform"
 	"github.com/orchestrator/unified-firewall/internal/tui/styles"
@@ -17,10 +18,26 @@ func (m *Model) updateStatus(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.screen = ScreenMenu
 			return m, nil
 		}
+		if key.Matches(msg.(tea.KeyMsg), key.NewBinding(key.WithKeys("a"))) && m.stateMgr != nil && m.stateMgr.Tampered() != nil {
+			return m.resealState()
+		}
 	}
 	return m, nil
 }
 
+// resealState accepts the state on disk as genuine after a manual edit
+func (m *Model) resealState() (tea.Model, tea.Cmd) {
+	m.loadingMsg = "Resealing state..."
+	m.screen = ScreenLoading
+
+	return m, func() tea.Msg {
+		if err := m.stateMgr.Reseal(); err != nil {
+			return errMsg{err}
+		}
+		return successMsg{"State accepted and resealed; changes are allowed again"}
+	}
+}
+
 func (m *Model) viewStatus() string {
 	title := styles.Title.Render("System Status")
 
@@ -50,15 +67,18 @@ func (m *Model) viewStatus() string {
 	providerPanel := styles.Panel.Width(35).Render(providerContent)
 
 	var productLines []string
-	products := installer.GetSupportedProducts()
-	for _, name := range products {
-		_, installed := platform.IsProductInstalled(name)
+	for _, name := range installer.GetSupportedProducts() {
+		product, _ := catalog.Default().Get(name)
+		_, installed := platform.IsProductInstalled(product.BinaryName())
 		if installed {
 			productLines = append(productLines, styles.Success.Render("✓ "+name))
 		} else {
 			productLines = append(productLines, styles.MutedColor+"✗ "+name)
 		}
 	}
+	for _, err := range catalog.Default().Errors {
+		productLines = append(productLines, styles.Warning.Render("! "+err.Error()))
+	}
 	productsContent := lipgloss.JoinVertical(lipgloss.Left, productLines...)
 	productsPanel := styles.Panel.Width(35).Render(productsContent)
 
@@ -66,14 +86,38 @@ func (m *Model) viewStatus() string {
 	if m.stateMgr != nil {
 		allRules := m.stateMgr.ListRules()
 		activeRules := m.stateMgr.ListActiveRules()
-		stateContent = fmt.Sprintf("Total: %d | Active: %d", len(allRules), len(activeRules))
+		natCount := 0
+		for _, r := range activeRules {
+			if r.IsNAT() {
+				natCount++
+			}
+		}
+		stateContent = lipgloss.JoinVertical(
+			lipgloss.Left,
+			fmt.Sprintf("Total: %d | Active: %d", len(allRules), len(activeRules)),
+			fmt.Sprintf("NAT: %d | Firewall: %d", natCount, len(activeRules)-natCount),
+		)
+		if err := m.stateMgr.Tampered(); err != nil {
+			stateContent = lipgloss.JoinVertical(
+				lipgloss.Left,
+				stateContent,
+				styles.Error.Render("Integrity: "+err.Error()),
+				styles.Warning.Render("Read-only; press a to accept"),
+			)
+		} else {
+			stateContent = lipgloss.JoinVertical(lipgloss.Left, stateContent, styles.Success.Render("Integrity: verified"))
+		}
 	}
 	statePanel := styles.Panel.Width(35).Render(stateContent)
 
 	topRow := lipgloss.JoinHorizontal(lipgloss.Top, osPanel, "  ", providerPanel)
 	bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, productsPanel, "  ", statePanel)
 
-	help := styles.Help.Render("esc: back")
+	helpText := "esc: back"
+	if m.stateMgr != nil && m.stateMgr.Tampered() != nil {
+		helpText = "a: accept and reseal state • esc: back"
+	}
+	help := styles.Help.Render(helpText)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
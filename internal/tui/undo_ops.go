@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/orchestrator/unified-firewall/internal/undo"
+)
+
+// undoLastChange reverses the most recent change
+func (m *Model) undoLastChange() (tea.Model, tea.Cmd) {
+	txn, entry, err := undo.Undo(m.provider, m.stateMgr)
+	if err != nil {
+		m.lastError = err
+		m.screen = ScreenError
+		return m, nil
+	}
+	return m.commitChange(txn, "Undoing last change...", fmt.Sprintf("Undone: %s", entry.Summary()), nil)
+}
+
+// redoLastChange re-applies the most recently undone change
+func (m *Model) redoLastChange() (tea.Model, tea.Cmd) {
+	txn, entry, err := undo.Redo(m.provider, m.stateMgr)
+	if err != nil {
+		m.lastError = err
+		m.screen = ScreenError
+		return m, nil
+	}
+	return m.commitChange(txn, "Redoing change...", fmt.Sprintf("Redone: %s", entry.Summary()), nil)
+}
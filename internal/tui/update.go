@@ -15,16 +15,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ruleSubMenuList.SetSize(msg.Width-4, msg.Height-8)
 		return m, nil
 
+	case pendingMsg:
+		return m.handlePending(msg)
+
+	case lockoutMsg:
+		return m.handleLockout(msg)
+
+	case followEventMsg:
+		return m.handleFollowEvent(msg)
+
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.Quit):
+			// Leaving an unconfirmed change applied is what confirm mode
+			// guards against, so revert it first
+			if m.pendingChange != nil {
+				return m.revertPendingChange("Reverting unconfirmed change before quitting...", true)
+			}
 			return m, tea.Quit
 		case key.Matches(msg, keys.Back):
 			switch m.screen {
 			case ScreenAddNATRule, ScreenOpenPort, ScreenOpenIPPort, ScreenOpenIP:
 				m.screen = ScreenAddRuleSelect // Go back to sub-menu
 				return m, nil
-			case ScreenAddRuleSelect, ScreenListRules, ScreenStatus, ScreenCheck, ScreenFirewall, ScreenSecurity:
+			case ScreenAddRuleSelect, ScreenListRules, ScreenStatus, ScreenCheck, ScreenFirewall, ScreenSecurity, ScreenDrift, ScreenExposure:
 				m.screen = ScreenMenu // Go back to main menu
 				m.lastError = nil
 				return m, nil
@@ -33,6 +47,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.screen = ScreenMenu
 				m.lastError = nil
 				m.successMsg = ""
+				m.verifyReport = nil
 				return m, nil
 			}
 		}
@@ -59,12 +74,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateCheck(msg)
 	case ScreenLoading:
 		return m.updateLoading(msg)
+	case ScreenConfirmChange:
+		return m.updateConfirmChange(msg)
+	case ScreenLockout:
+		return m.updateLockout(msg)
+	case ScreenDrift:
+		return m.updateDrift(msg)
+	case ScreenAdopt:
+		return m.updateAdopt(msg)
+	case ScreenHistory:
+		return m.updateHistory(msg)
+	case ScreenBackup:
+		return m.updateBackup(msg)
+	case ScreenExport:
+		return m.updateExport(msg)
+	case ScreenExpose:
+		return m.updateExpose(msg)
+	case ScreenExposure:
+		return m.updateExposure(msg)
+	case ScreenDenials:
+		return m.updateDenials(msg)
 	case ScreenError, ScreenSuccess:
 		// Handle any key to dismiss error/success screens
 		if _, ok := msg.(tea.KeyMsg); ok {
 			m.screen = ScreenMenu
 			m.lastError = nil
 			m.successMsg = ""
+			m.verifyReport = nil
 		}
 		return m, nil
 	}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/orchestrator/unified-firewall/internal/config"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/internal/tui/styles"
+	"github.com/orchestrator/unified-firewall/internal/verify"
+)
+
+// verifiedMsg is a success message with the reachability of the new rules
+type verifiedMsg struct {
+	msg    string
+	report *verify.Report
+}
+
+// verifyResult probes the rules a commit applied when verification is
+// enabled in the host configuration, or returns nil
+func (m *Model) verifyResult(result *transaction.Result) *verify.Report {
+	cfg, err := config.Load()
+	if err != nil || !cfg.Verify || result == nil {
+		return nil
+	}
+	return verify.Operations(m.ctx, result.Applied, verify.DefaultTimeout)
+}
+
+// renderVerifyReport renders one line per probe
+func renderVerifyReport(report *verify.Report) string {
+	lines := []string{styles.Subtitle.Render("Reachability")}
+	if report.Failed() {
+		lines = append(lines, styles.Error.Render("Some targets are unreachable; the rules stay applied"))
+	}
+	for _, p := range report.Probes {
+		switch p.Status {
+		case verify.StatusReachable:
+			lines = append(lines, styles.Success.Render("✓ "+p.String()))
+		case verify.StatusUnknown:
+			lines = append(lines, styles.Warning.Render("? "+p.String()))
+		case verify.StatusUnchecked:
+			lines = append(lines, styles.Info.Render("- "+p.String()))
+		default:
+			lines = append(lines, styles.Error.Render("✗ "+p.String()))
+		}
+		if p.Note != "" {
+			lines = append(lines, styles.Help.Render("  "+p.Note))
+		}
+	}
+	if len(report.Probes) == 0 {
+		lines = append(lines, styles.Info.Render("Nothing to probe"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -39,6 +39,26 @@ func (m *Model) View() string {
 		content = m.viewError()
 	case ScreenSuccess:
 		content = m.viewSuccess()
+	case ScreenConfirmChange:
+		content = m.viewConfirmChange()
+	case ScreenLockout:
+		content = m.viewLockout()
+	case ScreenDrift:
+		content = m.viewDrift()
+	case ScreenAdopt:
+		content = m.viewAdopt()
+	case ScreenHistory:
+		content = m.viewHistory()
+	case ScreenBackup:
+		content = m.viewBackup()
+	case ScreenExport:
+		content = m.viewExport()
+	case ScreenExpose:
+		content = m.viewExpose()
+	case ScreenExposure:
+		content = m.viewExposure()
+	case ScreenDenials:
+		content = m.viewDenials()
 	}
 
 	statusBar := m.renderStatusBar()
@@ -61,6 +81,12 @@ func (m *Model) renderStatusBar() string {
 		m.osInfo.Family,
 		providerName,
 	)
+	if m.stateMgr != nil && m.stateMgr.Tampered() != nil {
+		status += "| READ-ONLY: state failed integrity check "
+	}
+	if m.followNotice != "" {
+		status += "| " + m.followNotice + " "
+	}
 
 	return styles.StatusBar.Width(m.width).Render(status)
 }
@@ -0,0 +1,181 @@
+// Package undo reverses and re-applies recent changes. The undo and redo
+// stacks are derived from the audit journal, so they survive restarts.
+package undo
+
+import (
+	"fmt"
+
+	"github.com/orchestrator/unified-firewall/internal/audit"
+	"github.com/orchestrator/unified-firewall/internal/drivers"
+	"github.com/orchestrator/unified-firewall/internal/state"
+	"github.com/orchestrator/unified-firewall/internal/transaction"
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Depth is the number of changes that can be undone
+const Depth = 20
+
+// Stacks are the changes available to undo and redo, most recent last
+type Stacks struct {
+	Undo []audit.Entry
+	Redo []audit.Entry
+}
+
+// Load replays the journal to rebuild the stacks. Successful applies push
+// onto the undo stack and clear redo; undo and redo move the top entry
+// between the stacks, and a revert of an unconfirmed change drops it, or
+// moves it back when the change was itself an undo or redo.
+// Failed changes, state-only changes and changes Portly made on its own,
+// such as reconcile, restore and follow, are skipped.
+func Load(journal *audit.Journal) (*Stacks, error) {
+	entries, err := journal.Read(audit.Filter{})
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := &Stacks{}
+	var last audit.Entry
+	for _, entry := range entries {
+		if entry.Result != audit.ResultSuccess || len(operations(entry)) == 0 {
+			continue
+		}
+		switch entry.Action {
+		case audit.ActionApply:
+			last = entry
+			stacks.Undo = append(stacks.Undo, entry)
+			if len(stacks.Undo) > Depth {
+				stacks.Undo = stacks.Undo[1:]
+			}
+			stacks.Redo = nil
+		case audit.ActionUndo:
+			last = entry
+			if n := len(stacks.Undo); n > 0 {
+				stacks.Redo = append(stacks.Redo, stacks.Undo[n-1])
+				stacks.Undo = stacks.Undo[:n-1]
+			}
+		case audit.ActionRedo:
+			last = entry
+			if n := len(stacks.Redo); n > 0 {
+				stacks.Undo = append(stacks.Undo, stacks.Redo[n-1])
+				stacks.Redo = stacks.Redo[:n-1]
+			}
+		case audit.ActionRevert:
+			prev := last
+			last = entry
+			if prev.Action == audit.ActionUndo && reverts(entry, prev) {
+				if n := len(stacks.Redo); n > 0 {
+					stacks.Undo = append(stacks.Undo, stacks.Redo[n-1])
+					stacks.Redo = stacks.Redo[:n-1]
+				}
+				continue
+			}
+			if prev.Action == audit.ActionRedo && reverts(entry, prev) {
+				if n := len(stacks.Undo); n > 0 {
+					stacks.Redo = append(stacks.Redo, stacks.Undo[n-1])
+					stacks.Undo = stacks.Undo[:n-1]
+				}
+				continue
+			}
+			for i := len(stacks.Undo) - 1; i >= 0; i-- {
+				if reverts(entry, stacks.Undo[i]) {
+					stacks.Undo = append(stacks.Undo[:i], stacks.Undo[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return stacks, nil
+}
+
+// Undo stages the reversal of the most recent change as a transaction,
+// for the caller to commit, and returns the entry it reverses
+func Undo(provider drivers.Provider, stateMgr *state.Manager) (*transaction.Transaction, *audit.Entry, error) {
+	stacks, err := Load(audit.Default())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stacks.Undo) == 0 {
+		return nil, nil, fmt.Errorf("nothing to undo")
+	}
+	entry := stacks.Undo[len(stacks.Undo)-1]
+
+	txn := transaction.New(provider, stateMgr)
+	txn.SetAction(audit.ActionUndo)
+	for i := len(entry.Changes) - 1; i >= 0; i-- {
+		if op, ok := reverse(entry.Changes[i]); ok {
+			if err := txn.Stage(op); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return txn, &entry, nil
+}
+
+// Redo stages the most recently undone change again as a transaction, for
+// the caller to commit, and returns the entry it re-applies
+func Redo(provider drivers.Provider, stateMgr *state.Manager) (*transaction.Transaction, *audit.Entry, error) {
+	stacks, err := Load(audit.Default())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stacks.Redo) == 0 {
+		return nil, nil, fmt.Errorf("nothing to redo")
+	}
+	entry := stacks.Redo[len(stacks.Redo)-1]
+
+	txn := transaction.New(provider, stateMgr)
+	txn.SetAction(audit.ActionRedo)
+	for _, op := range operations(entry) {
+		if err := txn.Stage(op); err != nil {
+			return nil, nil, err
+		}
+	}
+	return txn, &entry, nil
+}
+
+// operations returns the operations recorded in an entry
+func operations(entry audit.Entry) []models.Operation {
+	var ops []models.Operation
+	for _, change := range entry.Changes {
+		if change.Operation != nil {
+			ops = append(ops, *change.Operation)
+		}
+	}
+	return ops
+}
+
+// reverse returns the operation undoing a change. A rule that is put back
+// is re-created from its state record, so it regains its original ID,
+// product and description.
+func reverse(change audit.Change) (models.Operation, bool) {
+	if change.Operation == nil {
+		return models.Operation{}, false
+	}
+	op := change.Operation.Inverse()
+	if before := change.Before; before != nil {
+		switch op.Kind {
+		case models.OpApplyNAT:
+			rule := before.NATRule
+			op.NAT = &rule
+		case models.OpOpenPort:
+			rule := before.FirewallRule()
+			op.Firewall = &rule
+		}
+	}
+	return op, true
+}
+
+// reverts reports whether revert applied the inverse of entry's operations
+func reverts(revert, entry audit.Entry) bool {
+	undone, applied := operations(revert), operations(entry)
+	if len(undone) != len(applied) {
+		return false
+	}
+	for i, op := range applied {
+		inverse := undone[len(undone)-1-i]
+		if op.Inverse().Kind != inverse.Kind || op.RuleID() != inverse.RuleID() {
+			return false
+		}
+	}
+	return true
+}
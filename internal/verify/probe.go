@@ -0,0 +1,115 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// Operations probes what each applied operation made reachable: a NAT
+// rule's internal target and forwarded port, and opened ports. Forwarded
+// and opened ports are dialed on this host's first non-loopback address,
+// so the connection never arrives from outside: most backends accept it
+// without consulting their input rules, and a forward is only translated
+// when the backend also applies it to locally generated traffic. Those
+// probes carry a note saying so. Removals are not probed.
+func Operations(ctx context.Context, ops []models.Operation, timeout time.Duration) *Report {
+	report := &Report{}
+	hostIP := primaryAddress()
+	for _, op := range ops {
+		switch {
+		case op.Kind == models.OpApplyNAT && op.NAT != nil:
+			rule := op.NAT
+			report.Probes = append(report.Probes, probe(ctx, rule.ID, PathTarget, rule.InternalIP, rule.InternalPort, rule.Proto, timeout))
+			report.Probes = append(report.Probes, probeHost(ctx, rule.ID, PathForward, hostIP, rule.ExternalPort, rule.Proto, timeout))
+		case op.Kind == models.OpOpenPort && op.Firewall != nil:
+			rule := op.Firewall
+			if rule.Type == models.RuleTypeTrustIP || rule.Port == 0 {
+				continue
+			}
+			report.Probes = append(report.Probes, probeHost(ctx, rule.ID, PathPort, hostIP, rule.Port, rule.Protocol, timeout))
+		}
+	}
+	return report
+}
+
+// probeHost probes a port on this host's own address, noting that the
+// result says nothing about the input filtering other hosts go through
+func probeHost(ctx context.Context, ruleID string, path Path, hostIP string, port int, proto models.Protocol, timeout time.Duration) Probe {
+	if hostIP == "" {
+		return Probe{
+			RuleID:  ruleID,
+			Path:    path,
+			Address: net.JoinHostPort("", strconv.Itoa(port)),
+			Proto:   proto,
+			Status:  StatusUnchecked,
+			Err:     errors.New("no non-loopback address to probe from"),
+		}
+	}
+	p := probe(ctx, ruleID, path, hostIP, port, proto, timeout)
+	p.Note = "dialed from this host's own IP, which bypasses input filtering on most backends; probe from another host to be sure"
+	return p
+}
+
+// probe connects to host:port. A TCP handshake proves reachability; a UDP
+// probe only fails when the port is reported closed.
+func probe(ctx context.Context, ruleID string, path Path, host string, port int, proto models.Protocol, timeout time.Duration) Probe {
+	p := Probe{RuleID: ruleID, Path: path, Address: net.JoinHostPort(host, strconv.Itoa(port)), Proto: proto}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, string(proto), p.Address)
+	if err != nil {
+		p.Status, p.Err = StatusUnreachable, err
+		return p
+	}
+	defer conn.Close()
+
+	if proto == models.UDP {
+		p.Status, p.Err = probeUDP(conn, timeout)
+	} else {
+		p.Status = StatusReachable
+	}
+	p.Latency = time.Since(start)
+	return p
+}
+
+// probeUDP sends an empty datagram. A reply means reachable, an ICMP port
+// unreachable surfaces as a refused read, and silence is inconclusive.
+func probeUDP(conn net.Conn, timeout time.Duration) (Status, error) {
+	if _, err := conn.Write([]byte{}); err != nil {
+		return StatusUnreachable, err
+	}
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	buf := make([]byte, 1)
+	_, err := conn.Read(buf)
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return StatusReachable, nil
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return StatusUnknown, nil
+	}
+	return StatusUnreachable, err
+}
+
+// primaryAddress returns the first non-loopback IPv4 address of this
+// host, so probes also reach services not bound to loopback
+func primaryAddress() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() && ipnet.IP.To4() != nil {
+			return ipnet.IP.String()
+		}
+	}
+	return ""
+}
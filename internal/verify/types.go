@@ -0,0 +1,81 @@
+// Package verify probes the targets of newly applied rules to check that
+// the services behind them are reachable
+package verify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/orchestrator/unified-firewall/pkg/models"
+)
+
+// DefaultTimeout bounds each probe
+const DefaultTimeout = 3 * time.Second
+
+// Status is the outcome of a probe
+type Status string
+
+const (
+	StatusReachable   Status = "reachable"
+	StatusUnreachable Status = "unreachable"
+	// StatusUnknown is a UDP probe without a reply, which cannot tell an
+	// open port from a filtered one
+	StatusUnknown Status = "unknown"
+	// StatusUnchecked is a path that cannot be probed because this host
+	// has no non-loopback address
+	StatusUnchecked Status = "unchecked"
+)
+
+// Path names the route a probe took
+type Path string
+
+const (
+	// PathTarget connects to a NAT rule's internal target directly
+	PathTarget Path = "target"
+	// PathForward connects to a NAT rule's external port on this host's
+	// own address
+	PathForward Path = "forward"
+	// PathPort connects to an opened port on this host
+	PathPort Path = "port"
+)
+
+// Probe is one connection attempt
+type Probe struct {
+	RuleID  string
+	Path    Path
+	Address string
+	Proto   models.Protocol
+	Status  Status
+	Latency time.Duration
+	Err     error
+	// Note qualifies what the status proves, such as a probe that never
+	// left this host
+	Note string
+}
+
+// String returns a human-readable representation of the probe
+func (p Probe) String() string {
+	s := fmt.Sprintf("%s %s %s/%s: %s", p.RuleID, p.Path, p.Address, p.Proto, p.Status)
+	if p.Status == StatusReachable {
+		s += fmt.Sprintf(" (%s)", p.Latency.Round(time.Millisecond))
+	}
+	if p.Err != nil {
+		s += fmt.Sprintf(" (%v)", p.Err)
+	}
+	return s
+}
+
+// Report holds the probes for one change
+type Report struct {
+	Probes []Probe
+}
+
+// Failed reports whether any probe found its target unreachable
+func (r *Report) Failed() bool {
+	for _, p := range r.Probes {
+		if p.Status == StatusUnreachable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package models
+
+// AppliedPolicy records the security changes Portly made for a product, so
+// they can be undone when its last rule goes away
+type AppliedPolicy struct {
+	Product string `yaml:"product" json:"product"`
+	// Booleans were off and turned on for the product
+	Booleans []string `yaml:"booleans,omitempty" json:"booleans,omitempty"`
+	// Needs are booleans a fix asked for that were already on, so they
+	// stay on while the product has rules
+	Needs []string `yaml:"needs,omitempty" json:"needs,omitempty"`
+	// AppArmorProfile is the profile loaded for the product
+	AppArmorProfile string `yaml:"apparmor_profile,omitempty" json:"apparmor_profile,omitempty"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import "fmt"
+
+// NewAppliedNATRule wraps a NAT rule for persistence
+func NewAppliedNATRule(rule NATRule, status RuleStatus) AppliedRule {
+	return AppliedRule{NATRule: rule, Type: RuleTypeNAT, Status: status}
+}
+
+// NewAppliedFirewallRule wraps a firewall rule for persistence
+func NewAppliedFirewallRule(rule FirewallRule, status RuleStatus) AppliedRule {
+	ruleType := rule.Type
+	if ruleType == "" {
+		ruleType = RuleTypePort
+	}
+	return AppliedRule{
+		NATRule: NATRule{
+			ID:          rule.ID,
+			Product:     rule.Product,
+			Proto:       rule.Protocol,
+			Description: rule.Description,
+		},
+		Type:     ruleType,
+		Port:     rule.Port,
+		SourceIP: rule.SourceIP,
+		Status:   status,
+	}
+}
+
+// RuleType returns the kind of rule. Records written before firewall rules
+// were tracked have no type and are NAT rules.
+func (r *AppliedRule) RuleType() FirewallRuleType {
+	if r.Type == "" {
+		return RuleTypeNAT
+	}
+	return r.Type
+}
+
+// IsNAT returns true if the record holds a NAT rule
+func (r *AppliedRule) IsNAT() bool {
+	return r.RuleType() == RuleTypeNAT
+}
+
+// FirewallRule returns the record as a firewall rule
+func (r *AppliedRule) FirewallRule() FirewallRule {
+	return FirewallRule{
+		ID:          r.ID,
+		Type:        r.RuleType(),
+		Port:        r.Port,
+		Protocol:    r.Proto,
+		SourceIP:    r.SourceIP,
+		Description: r.Description,
+		Product:     r.Product,
+	}
+}
+
+// Operation returns the operation that (re)creates the recorded rule
+func (r *AppliedRule) Operation() Operation {
+	if r.IsNAT() {
+		rule := r.NATRule
+		return Operation{Kind: OpApplyNAT, NAT: &rule}
+	}
+	rule := r.FirewallRule()
+	return Operation{Kind: OpOpenPort, Firewall: &rule}
+}
+
+// String returns a human-readable representation of the record
+func (r *AppliedRule) String() string {
+	if r.IsNAT() {
+		return fmt.Sprintf("%s [%s]", r.NATRule.String(), r.Status)
+	}
+	fw := r.FirewallRule()
+	return fmt.Sprintf("%s [%s]", fw.String(), r.Status)
+}
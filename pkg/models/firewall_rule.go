@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 // FirewallRuleType represents the type of firewall rule
@@ -63,3 +64,23 @@ func (r *FirewallRule) IsPortOpen() bool {
 func (r *FirewallRule) IsIPLimited() bool {
 	return r.Type == RuleTypePortLimit && r.SourceIP != ""
 }
+
+// Matches reports whether two firewall rules admit the same traffic,
+// ignoring IDs and metadata that backends do not preserve
+func (r *FirewallRule) Matches(other FirewallRule) bool {
+	if r.kind() != other.kind() || r.SourceIP != other.SourceIP {
+		return false
+	}
+	if r.kind() == RuleTypeTrustIP {
+		return true
+	}
+	return r.Port == other.Port && strings.EqualFold(string(r.Protocol), string(other.Protocol))
+}
+
+// kind returns the rule type, treating an unset type as a plain port
+func (r *FirewallRule) kind() FirewallRuleType {
+	if r.Type == "" {
+		return RuleTypePort
+	}
+	return r.Type
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 // NATRule represents a single NAT/port forwarding rule
@@ -14,6 +15,8 @@ type NATRule struct {
 	InternalPort int      `yaml:"internal_port" json:"internal_port"`
 	Proto        Protocol `yaml:"protocol" json:"protocol"`
 	Description  string   `yaml:"description" json:"description"`
+	// Container names the podman/docker container the rule forwards to
+	Container string `yaml:"container,omitempty" json:"container,omitempty"`
 }
 
 // Validate checks if the NAT rule has valid fields
@@ -44,3 +47,12 @@ func (r *NATRule) String() string {
 	return fmt.Sprintf("%s: %s (%d) -> %s:%d/%s",
 		r.Product, r.ID, r.ExternalPort, r.InternalIP, r.InternalPort, r.Proto)
 }
+
+// Matches reports whether two NAT rules describe the same forward, ignoring
+// IDs and metadata that backends do not preserve
+func (r *NATRule) Matches(other NATRule) bool {
+	return r.ExternalPort == other.ExternalPort &&
+		strings.EqualFold(string(r.Proto), string(other.Proto)) &&
+		r.InternalIP == other.InternalIP &&
+		r.InternalPort == other.InternalPort
+}
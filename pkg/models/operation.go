@@ -0,0 +1,76 @@
+package models
+
+import "fmt"
+
+// OperationKind identifies a change staged against a firewall provider
+type OperationKind string
+
+const (
+	OpApplyNAT  OperationKind = "apply_nat"
+	OpRemoveNAT OperationKind = "remove_nat"
+	OpOpenPort  OperationKind = "open_port"
+	OpClosePort OperationKind = "close_port"
+)
+
+// Operation is a single provider change. Removals carry the full rule so
+// that the operation can always be reversed.
+type Operation struct {
+	Kind     OperationKind `yaml:"kind" json:"kind"`
+	NAT      *NATRule      `yaml:"nat,omitempty" json:"nat,omitempty"`
+	Firewall *FirewallRule `yaml:"firewall,omitempty" json:"firewall,omitempty"`
+}
+
+// Validate checks that the operation carries the rule its kind needs
+func (o Operation) Validate() error {
+	switch o.Kind {
+	case OpApplyNAT, OpRemoveNAT:
+		if o.NAT == nil {
+			return fmt.Errorf("%s operation requires a NAT rule", o.Kind)
+		}
+	case OpOpenPort, OpClosePort:
+		if o.Firewall == nil {
+			return fmt.Errorf("%s operation requires a firewall rule", o.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown operation kind '%s'", o.Kind)
+	}
+	return nil
+}
+
+// Inverse returns the operation that undoes this one
+func (o Operation) Inverse() Operation {
+	inv := Operation{NAT: o.NAT, Firewall: o.Firewall}
+	switch o.Kind {
+	case OpApplyNAT:
+		inv.Kind = OpRemoveNAT
+	case OpRemoveNAT:
+		inv.Kind = OpApplyNAT
+	case OpOpenPort:
+		inv.Kind = OpClosePort
+	case OpClosePort:
+		inv.Kind = OpOpenPort
+	}
+	return inv
+}
+
+// RuleID returns the ID of the rule the operation acts on
+func (o Operation) RuleID() string {
+	if o.NAT != nil {
+		return o.NAT.ID
+	}
+	if o.Firewall != nil {
+		return o.Firewall.ID
+	}
+	return ""
+}
+
+// String returns a human-readable representation of the operation
+func (o Operation) String() string {
+	if o.NAT != nil {
+		return fmt.Sprintf("%s %s", o.Kind, o.NAT.String())
+	}
+	if o.Firewall != nil {
+		return fmt.Sprintf("%s %s", o.Kind, o.Firewall.String())
+	}
+	return string(o.Kind)
+}
@@ -0,0 +1,23 @@
+package models
+
+// PortLabelRecord is an SELinux port label Portly added or changed, so that
+// only labels Portly owns are ever removed
+type PortLabelRecord struct {
+	Port    int      `yaml:"port" json:"port"`
+	Proto   Protocol `yaml:"protocol" json:"protocol"`
+	Type    string   `yaml:"type" json:"type"`
+	Product string   `yaml:"product,omitempty" json:"product,omitempty"`
+	// Previous is the type a local label had before Portly modified it;
+	// empty when Portly added the label
+	Previous string `yaml:"previous,omitempty" json:"previous,omitempty"`
+}
+
+// FindPortLabel finds the record for a port label
+func (s *State) FindPortLabel(port int, proto Protocol) *PortLabelRecord {
+	for i := range s.PortLabels {
+		if s.PortLabels[i].Port == port && s.PortLabels[i].Proto == proto {
+			return &s.PortLabels[i]
+		}
+	}
+	return nil
+}
@@ -8,12 +8,24 @@ const (
 	StatusPending RuleStatus = "pending"
 	StatusFailed  RuleStatus = "failed"
 	StatusRemoved RuleStatus = "removed"
+	// StatusDisabled marks a rule taken off the firewall but kept so that
+	// it can be re-applied
+	StatusDisabled RuleStatus = "disabled"
 )
 
-// AppliedRule tracks a rule that has been applied to the system
+// AppliedRule tracks a rule that has been applied to the system. NAT rules
+// use the embedded NATRule fields; firewall rules share ID, Product,
+// Description and protocol with them and add Type, Port and SourceIP.
 type AppliedRule struct {
 	NATRule   `yaml:",inline" json:",inline"`
-	Status    RuleStatus `yaml:"status" json:"status"`
-	AppliedAt string     `yaml:"applied_at" json:"applied_at"`
-	ErrorMsg  string     `yaml:"error_msg,omitempty" json:"error_msg,omitempty"`
+	Type      FirewallRuleType `yaml:"type,omitempty" json:"type,omitempty"`
+	Port      int              `yaml:"port,omitempty" json:"port,omitempty"`
+	SourceIP  string           `yaml:"source_ip,omitempty" json:"source_ip,omitempty"`
+	Status    RuleStatus       `yaml:"status" json:"status"`
+	AppliedAt string           `yaml:"applied_at" json:"applied_at"`
+	UpdatedAt string           `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	ErrorMsg  string           `yaml:"error_msg,omitempty" json:"error_msg,omitempty"`
+	// Group ties together rules created as one unit, such as all ports of
+	// an exposed product
+	Group string `yaml:"group,omitempty" json:"group,omitempty"`
 }
@@ -7,6 +7,23 @@ type State struct {
 	Rules       []AppliedRule          `yaml:"rules" json:"rules"`
 	Products    map[string]ProductInfo `yaml:"products" json:"products"`
 	LastUpdated string                 `yaml:"last_updated" json:"last_updated"`
+	// PortLabels are the SELinux port labels Portly added or changed
+	PortLabels []PortLabelRecord `yaml:"port_labels,omitempty" json:"port_labels,omitempty"`
+	// Policies are the other security changes Portly made, by product
+	Policies map[string]AppliedPolicy `yaml:"policies,omitempty" json:"policies,omitempty"`
+	// Revision is incremented on every save so concurrent writers can tell
+	// whether the file advanced since they loaded it
+	Revision int64 `yaml:"revision,omitempty" json:"revision,omitempty"`
+	// JournalHead and JournalEntries are the seal of the audit journal's
+	// last entry and its length when the state was saved, so a journal cut
+	// short no longer matches the state
+	JournalHead    string `yaml:"journal_head,omitempty" json:"journal_head,omitempty"`
+	JournalEntries int    `yaml:"journal_entries,omitempty" json:"journal_entries,omitempty"`
+	// Integrity is an HMAC over the rest of the state
+	Integrity string `yaml:"integrity,omitempty" json:"integrity,omitempty"`
+	// SourceVersion is the schema version the state was read with, before
+	// migration; it is not stored
+	SourceVersion string `yaml:"-" json:"-"`
 }
 
 // FindRule finds a rule by ID